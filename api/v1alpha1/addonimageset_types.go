@@ -70,8 +70,7 @@ type AddonImageSetSpec struct {
 
 // AddonImageSetStatus defines the observed state of AddonImageSet
 type AddonImageSetStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ValidationStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -30,3 +30,9 @@ func (a *AddonImageSetSpec) GetSemver() (string, error) {
 	}
 	return "", fmt.Errorf("Could not parse the imageSet name as a valid semver, %v.", a.Name)
 }
+
+// GetAddonID - Returns the id of the addon the imageset belongs to
+// which is the part of its name preceding the version.
+func (a *AddonImageSetSpec) GetAddonID() string {
+	return strings.SplitN(a.Name, ".", 2)[0]
+}
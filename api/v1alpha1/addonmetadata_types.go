@@ -214,8 +214,7 @@ type AddonMetadataSpec struct {
 
 // AddonMetadataStatus defines the observed state of AddonMetadata
 type AddonMetadataStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ValidationStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
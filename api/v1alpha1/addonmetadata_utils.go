@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"errors"
 
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
 	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
@@ -17,6 +19,24 @@ func (a *AddonMetadata) ToJSON() ([]byte, error) {
 	return json.Marshal(a)
 }
 
+var (
+	ErrConflictingIndexImageSource = errors.New("can't set both the 'indexImage' and the 'addonImageSetVersion' field")
+	ErrMissingIndexImageSource     = errors.New("one of the 'indexImage' or the 'addonImageSetVersion' field must be set")
+)
+
+// VerifyIndexImageSource - Returns an error unless exactly one of the
+// 'indexImage' and the 'addonImageSetVersion' field is set.
+func (a *AddonMetadataSpec) VerifyIndexImageSource() error {
+	switch {
+	case a.IndexImage != nil && a.ImageSetVersion != nil:
+		return ErrConflictingIndexImageSource
+	case a.IndexImage == nil && a.ImageSetVersion == nil:
+		return ErrMissingIndexImageSource
+	default:
+		return nil
+	}
+}
+
 // CombineWithImageSet - Returns a new AddonMetadataSpec combined with the
 // related imageSet fields. Using deep copy to avoid overriding the existing CR.
 func (a *AddonMetadataSpec) CombineWithImageSet(imageSet *AddonImageSetSpec) (*AddonMetadataSpec, error) {
@@ -49,5 +69,11 @@ func (a *AddonMetadataSpec) CombineWithImageSet(imageSet *AddonImageSetSpec) (*A
 		combined.SubOperators = &subOperators
 	}
 
+	if imageSet.AdditionalCatalogSources != nil {
+		sources := make([]mtsrev1.AdditionalCatalogSource, len(*imageSet.AdditionalCatalogSources))
+		copy(sources, *imageSet.AdditionalCatalogSources)
+		combined.AdditionalCatalogSources = &sources
+	}
+
 	return combined, nil
 }
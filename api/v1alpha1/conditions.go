@@ -0,0 +1,23 @@
+package v1alpha1
+
+const (
+	// ConditionValidated is 'True' if no validator failed at error
+	// severity for the object, 'False' if at least one did and
+	// 'Unknown' if validation could not be completed.
+	ConditionValidated = "Validated"
+	// ConditionImageSetResolved is 'True' if the AddonImageSet
+	// referenced by an AddonMetadata exists.
+	ConditionImageSetResolved = "ImageSetResolved"
+)
+
+const (
+	ReasonValidationSucceeded    = "ValidationSucceeded"
+	ReasonValidationFailed       = "ValidationFailed"
+	ReasonValidationErrored      = "ValidationErrored"
+	ReasonImageSetFound          = "ImageSetFound"
+	ReasonImageSetNotFound       = "ImageSetNotFound"
+	ReasonStaticIndexImage       = "StaticIndexImage"
+	ReasonInvalidMetadata        = "InvalidMetadata"
+	ReasonAddonMetadataNotFound  = "AddonMetadataNotFound"
+	ReasonBundleExtractionFailed = "BundleExtractionFailed"
+)
@@ -0,0 +1,103 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidationStatus describes the outcome of validating an addon and
+// is shared by the status of AddonMetadata and AddonImageSet objects.
+type ValidationStatus struct {
+	// +optional
+	// The generation of the object which was last validated.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions describing the result of the last validation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// The index image the bundles were extracted from.
+	IndexImage string `json:"indexImage,omitempty"`
+
+	// +optional
+	// The bundles of the addon's operator which were validated.
+	Bundles []BundleReference `json:"bundles,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=code
+	// The results of the individual validators.
+	Results []ValidatorResult `json:"results,omitempty"`
+}
+
+// BundleReference identifies a validated bundle.
+type BundleReference struct {
+	// The name of the bundle's ClusterServiceVersion.
+	Name string `json:"name"`
+
+	// +optional
+	// The version of the bundle's ClusterServiceVersion.
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	// The image the bundle was extracted from.
+	Image string `json:"image,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Success;Failure;Error;Suppressed;Skipped
+// ValidatorResultStatus is the outcome of running a single validator.
+type ValidatorResultStatus string
+
+const (
+	ValidatorResultSuccess    ValidatorResultStatus = "Success"
+	ValidatorResultFailure    ValidatorResultStatus = "Failure"
+	ValidatorResultError      ValidatorResultStatus = "Error"
+	ValidatorResultSuppressed ValidatorResultStatus = "Suppressed"
+	ValidatorResultSkipped    ValidatorResultStatus = "Skipped"
+)
+
+// ValidatorResult is the result of running a single validator.
+type ValidatorResult struct {
+	// The code of the validator e.g. 'AM0001'.
+	Code string `json:"code"`
+
+	// The name of the validator.
+	Name string `json:"name"`
+
+	// The severity of failures reported by the validator.
+	Severity string `json:"severity"`
+
+	// The outcome of running the validator.
+	Status ValidatorResultStatus `json:"status"`
+
+	// +optional
+	// The reasons the validator failed.
+	FailureMsgs []string `json:"failureMessages,omitempty"`
+
+	// +optional
+	// The reasons the validator failed which were accepted
+	// by a suppression.
+	SuppressedMsgs []string `json:"suppressedMessages,omitempty"`
+
+	// +optional
+	// The error the validator encountered.
+	Error string `json:"error,omitempty"`
+}
@@ -1,5 +1,4 @@
 //go:build !ignore_autogenerated
-// +build !ignore_autogenerated
 
 /*
 Copyright 2021.
@@ -24,6 +23,7 @@ package v1alpha1
 import (
 	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -33,7 +33,7 @@ func (in *AddonImageSet) DeepCopyInto(out *AddonImageSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonImageSet.
@@ -154,6 +154,7 @@ func (in *AddonImageSetSpec) DeepCopy() *AddonImageSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonImageSetStatus) DeepCopyInto(out *AddonImageSetStatus) {
 	*out = *in
+	in.ValidationStatus.DeepCopyInto(&out.ValidationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonImageSetStatus.
@@ -172,7 +173,7 @@ func (in *AddonMetadata) DeepCopyInto(out *AddonMetadata) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMetadata.
@@ -338,6 +339,16 @@ func (in *AddonMetadataSpec) DeepCopyInto(out *AddonMetadataSpec) {
 		*out = new(mtsrev1.Monitoring)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetricsFederation != nil {
+		in, out := &in.MetricsFederation, &out.MetricsFederation
+		*out = new(mtsrev1.MetricsFederation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitoringStack != nil {
+		in, out := &in.MonitoringStack, &out.MonitoringStack
+		*out = new(mtsrev1.MonitoringStack)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BundleParameters != nil {
 		in, out := &in.BundleParameters, &out.BundleParameters
 		*out = new(mtsrev1.BundleParameters)
@@ -417,6 +428,7 @@ func (in *AddonMetadataSpec) DeepCopy() *AddonMetadataSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonMetadataStatus) DeepCopyInto(out *AddonMetadataStatus) {
 	*out = *in
+	in.ValidationStatus.DeepCopyInto(&out.ValidationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMetadataStatus.
@@ -429,6 +441,21 @@ func (in *AddonMetadataStatus) DeepCopy() *AddonMetadataStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleReference) DeepCopyInto(out *BundleReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleReference.
+func (in *BundleReference) DeepCopy() *BundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Channel) DeepCopyInto(out *Channel) {
 	*out = *in
@@ -443,3 +470,62 @@ func (in *Channel) DeepCopy() *Channel {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationStatus) DeepCopyInto(out *ValidationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]BundleReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]ValidatorResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationStatus.
+func (in *ValidationStatus) DeepCopy() *ValidationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatorResult) DeepCopyInto(out *ValidatorResult) {
+	*out = *in
+	if in.FailureMsgs != nil {
+		in, out := &in.FailureMsgs, &out.FailureMsgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SuppressedMsgs != nil {
+		in, out := &in.SuppressedMsgs, &out.SuppressedMsgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorResult.
+func (in *ValidatorResult) DeepCopy() *ValidatorResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatorResult)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/controllers"
+	"github.com/mt-sre/addon-metadata-operator/internal/pipeline"
+	"github.com/mt-sre/addon-metadata-operator/internal/webhooks"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	ocmTokenEnvVar        = "OCM_TOKEN"
+	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
+	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
+)
+
+type options struct {
+	MetricsAddr          string
+	ProbeAddr            string
+	EnableLeaderElection bool
+	EnableWebhooks       bool
+	WebhookPort          int
+	WebhookCertDir       string
+	Env                  string
+	ValidatorTimeout     time.Duration
+}
+
+func main() {
+	opts := options{
+		MetricsAddr:      ":8080",
+		ProbeAddr:        ":8081",
+		EnableWebhooks:   true,
+		WebhookPort:      9443,
+		Env:              "production",
+		ValidatorTimeout: 2 * time.Minute,
+	}
+
+	flag.StringVar(&opts.MetricsAddr, "metrics-bind-address", opts.MetricsAddr, "The address the metric endpoint binds to.")
+	flag.StringVar(&opts.ProbeAddr, "health-probe-bind-address", opts.ProbeAddr, "The address the probe endpoint binds to.")
+	flag.BoolVar(&opts.EnableLeaderElection, "leader-elect", opts.EnableLeaderElection,
+		"Enable leader election for the controller manager to ensure there is only one active instance.")
+	flag.BoolVar(&opts.EnableWebhooks, "enable-webhooks", opts.EnableWebhooks,
+		"Serve the defaulting and validating admission webhooks.")
+	flag.IntVar(&opts.WebhookPort, "webhook-port", opts.WebhookPort, "The port the webhook server binds to.")
+	flag.StringVar(&opts.WebhookCertDir, "webhook-cert-dir", opts.WebhookCertDir,
+		"The directory containing the webhook server's tls.crt and tls.key. Defaults to <temp-dir>/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&opts.Env, "env", opts.Env, "OCM environment used by validators: integration, stage or production.")
+	flag.DurationVar(&opts.ValidatorTimeout, "validator-timeout", opts.ValidatorTimeout,
+		"Deadline of each validator run during reconciliation. Validators exceeding it report an error.")
+
+	zapOpts := zap.Options{}
+	zapOpts.BindFlags(flag.CommandLine)
+
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts)))
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	log := ctrl.Log.WithName("setup")
+
+	if !pipeline.IsValidEnv(opts.Env) {
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", opts.Env)
+	}
+
+	scheme := runtime.NewScheme()
+
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("adding client-go types to scheme: %w", err)
+	}
+
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("adding addon types to scheme: %w", err)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: opts.MetricsAddr},
+		HealthProbeBindAddress: opts.ProbeAddr,
+		LeaderElection:         opts.EnableLeaderElection,
+		LeaderElectionID:       "addon-metadata-operator.addonsflow.redhat.openshift.io",
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    opts.WebhookPort,
+			CertDir: opts.WebhookCertDir,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("creating manager: %w", err)
+	}
+
+	ocm, err := validator.NewOCMClient(
+		validator.WithConnectOptions{
+			validator.WithAPIURL(pipeline.OCMURL(opts.Env)),
+			validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
+			validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
+			validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing ocm client: %w", err)
+	}
+
+	defer func() { _ = ocm.CloseConnection() }()
+
+	shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		return fmt.Errorf("setting up tracing: %w", err)
+	}
+
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
+	validatorMetrics := validator.NewMetricsMiddleware()
+
+	if err := metrics.Registry.Register(validatorMetrics); err != nil {
+		return fmt.Errorf("registering validator metrics: %w", err)
+	}
+
+	runner, err := validator.NewRunner(
+		validator.WithLogger{Logger: ctrl.Log.WithName("validator")},
+		validator.WithMiddleware{
+			validator.NewTimeoutMiddleware(validator.WithDefaultTimeout(opts.ValidatorTimeout)),
+			validator.NewRetryMiddleware(),
+			validatorMetrics,
+			validator.NewTracingMiddleware(),
+		},
+		validator.WithOCMClient{OCMClient: ocm},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing validators: %w", err)
+	}
+
+	metaValidator := controllers.NewRunnerValidator(extractor.New(), runner)
+
+	if err := (&controllers.AddonMetadataReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("AddonMetadata"),
+		Validator: metaValidator,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up AddonMetadata controller: %w", err)
+	}
+
+	if err := (&controllers.AddonImageSetReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("AddonImageSet"),
+		Validator: metaValidator,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up AddonImageSet controller: %w", err)
+	}
+
+	if opts.EnableWebhooks {
+		if err := setupWebhooks(mgr); err != nil {
+			return err
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return fmt.Errorf("adding health check: %w", err)
+	}
+
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return fmt.Errorf("adding ready check: %w", err)
+	}
+
+	log.Info("starting manager")
+
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		return fmt.Errorf("running manager: %w", err)
+	}
+
+	return nil
+}
+
+// setupTracing exports traces of validator runs through OTLP if an
+// exporter endpoint is configured through the standard OTEL_*
+// environment variables. The returned function flushes and stops
+// the exporter.
+func setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// webhookValidatorTimeout keeps admission well within
+// the API server's webhook timeout.
+const webhookValidatorTimeout = 5 * time.Second
+
+func setupWebhooks(mgr ctrl.Manager) error {
+	// admission only runs offline validators so
+	// no OCM or registry clients are configured
+	runner, err := validator.NewRunner(
+		validator.WithLogger{Logger: ctrl.Log.WithName("webhooks").WithName("validator")},
+		validator.WithMiddleware{
+			validator.NewTimeoutMiddleware(validator.WithDefaultTimeout(webhookValidatorTimeout)),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing webhook validators: %w", err)
+	}
+
+	if err := (&webhooks.AddonMetadataWebhook{
+		Client: mgr.GetClient(),
+		Runner: runner,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up AddonMetadata webhook: %w", err)
+	}
+
+	if err := (&webhooks.AddonImageSetWebhook{
+		Client: mgr.GetClient(),
+		Runner: runner,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up AddonImageSet webhook: %w", err)
+	}
+
+	return nil
+}
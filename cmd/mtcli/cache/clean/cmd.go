@@ -0,0 +1,77 @@
+package clean
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Remove all cached bundles and index listings.",
+		"  mtcli cache clean",
+		"  # Only remove expired entries.",
+		"  mtcli cache clean --expired",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	var expired bool
+
+	opts := cli.NewCacheOptions()
+
+	cmd := &cobra.Command{
+		Use:     "clean",
+		Short:   "Remove cached bundles and index listings.",
+		Example: examples(),
+		Args:    cobra.NoArgs,
+		RunE:    run(&opts, &expired),
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddCacheDirFlag(flags)
+
+	flags.BoolVar(
+		&expired,
+		"expired",
+		expired,
+		"Only remove expired entries.",
+	)
+
+	flags.DurationVar(
+		&opts.TTL,
+		"cache-ttl",
+		opts.TTL,
+		"Maximum age of entries referenced by tag when removing expired entries.",
+	)
+
+	return cmd
+}
+
+func run(opts *cli.CacheOptions, expired *bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		bundles, indexes := opts.Stores()
+
+		for _, s := range []interface {
+			Prune() error
+			Clear() error
+		}{indexes, bundles} {
+			var err error
+
+			if *expired {
+				err = s.Prune()
+			} else {
+				err = s.Clear()
+			}
+
+			if err != nil {
+				return fmt.Errorf("cleaning cache: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package cache
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/cache/clean"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/cache/list"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "Inspect or clean the cache of extracted bundles and index listings.",
+	}
+
+	cmd.AddCommand(clean.Cmd())
+	cmd.AddCommand(list.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/spf13/cobra"
+)
+
+func examples() string {
+	return strings.Join([]string{
+		"  # List all cached bundles and index listings.",
+		"  mtcli cache list",
+		"  # List the entries of a cache in a custom directory.",
+		"  mtcli cache list --cache-dir <path/to/cache_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := cli.NewCacheOptions()
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the cached bundles and index listings.",
+		Example: examples(),
+		Args:    cobra.NoArgs,
+		RunE:    run(&opts),
+	}
+
+	opts.AddCacheDirFlag(cmd.Flags())
+
+	return cmd
+}
+
+func run(opts *cli.CacheOptions) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		table, err := cli.NewTable(
+			cli.WithHeaders{"KIND", "KEY", "SIZE", "CREATED", "LAST USED", "EXPIRED"},
+		)
+		if err != nil {
+			return fmt.Errorf("initializing table: %w", err)
+		}
+
+		bundles, indexes := opts.Stores()
+
+		for _, s := range []struct {
+			Kind  string
+			Store *extractor.DiskStore
+		}{
+			{Kind: "index", Store: indexes},
+			{Kind: "bundle", Store: bundles},
+		} {
+			entries, err := s.Store.Entries()
+			if err != nil {
+				return fmt.Errorf("listing %s entries: %w", s.Kind, err)
+			}
+
+			for _, e := range entries {
+				table.WriteRow(cli.TableRow{
+					cli.Field{Value: s.Kind},
+					cli.Field{Value: e.Key},
+					cli.Field{Value: fmt.Sprintf("%.1fKiB", float64(e.Size)/1024)},
+					cli.Field{Value: e.CreatedAt.Format(time.RFC3339)},
+					cli.Field{Value: e.LastUsed.Format(time.RFC3339)},
+					cli.Field{Value: fmt.Sprint(e.Expired)},
+				})
+			}
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), table.String())
+
+		return nil
+	}
+}
@@ -0,0 +1,236 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/diff"
+	"github.com/mt-sre/addon-metadata-operator/internal/pipeline"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Show the differences between two imageset versions of a staging addon.",
+		"  mtcli diff --from 1.0.0 --to 1.1.0 <path/to/addon_dir>",
+		"  # Compare the latest imageset of a production addon against a previous version as JSON.",
+		"  mtcli diff --env production --from 1.0.0 --to latest --output json <path/to/addon_dir>",
+	}, "\n")
+}
+
+// formats are the report formats supported by diff.
+var formats = []report.Format{
+	report.FormatTable,
+	report.FormatJSON,
+}
+
+type options struct {
+	Env    string
+	From   string
+	To     string
+	Output report.Format
+	Cache  cli.CacheOptions
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env:    "stage",
+		Output: report.FormatTable,
+		Cache:  cli.NewCacheOptions(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Short:   "Show the differences between two imageset versions of an addon and their bundles.",
+		Example: examples(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    run(opts),
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddFlags(flags)
+	opts.Cache.AddFlags(flags)
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+
+	flags.StringVar(
+		&o.From,
+		"from",
+		o.From,
+		"addon imageset version to compare from",
+	)
+
+	flags.StringVar(
+		&o.To,
+		"to",
+		o.To,
+		"addon imageset version to compare to",
+	)
+
+	flags.Var(
+		(*formatValue)(&o.Output),
+		"output",
+		fmt.Sprintf("Output format of the differences. One of: %s.", formatNames()),
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	if !pipeline.IsValidEnv(o.Env) {
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	return nil
+}
+
+// formatValue implements pflag.Value for report.Format so that
+// formats not supported by diff are rejected while parsing flags.
+type formatValue report.Format
+
+func (f *formatValue) String() string { return string(*f) }
+
+func (f *formatValue) Set(s string) error {
+	format, err := report.ParseFormat(s)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(formats, format) {
+		return fmt.Errorf("%q is not supported by diff; must be one of: %s", s, formatNames())
+	}
+
+	*f = formatValue(format)
+
+	return nil
+}
+
+func (f *formatValue) Type() string { return "format" }
+
+func formatNames() string {
+	names := make([]string, 0, len(formats))
+
+	for _, f := range formats {
+		names = append(names, string(f))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		addonDir := args[0]
+		ex := extractor.New(opts.Cache.ExtractorOptions(validator.NewRegistryClient())...)
+
+		from, err := diff.Load(cmd.Context(), ex, addonDir, opts.Env, opts.From)
+		if err != nil {
+			return fmt.Errorf("loading version %q: %w", opts.From, err)
+		}
+
+		to, err := diff.Load(cmd.Context(), ex, addonDir, opts.Env, opts.To)
+		if err != nil {
+			return fmt.Errorf("loading version %q: %w", opts.To, err)
+		}
+
+		changes, err := diff.Compare(from, to)
+		if err != nil {
+			return fmt.Errorf("comparing versions: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+
+		if opts.Output == report.FormatJSON {
+			return writeJSON(out, opts, changes)
+		}
+
+		return writeTable(out, changes)
+	}
+}
+
+type jsonDiff struct {
+	Env     string        `json:"env"`
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Changes []diff.Change `json:"changes"`
+}
+
+func writeJSON(out io.Writer, opts *options, changes []diff.Change) error {
+	if changes == nil {
+		changes = []diff.Change{}
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jsonDiff{
+		Env:     opts.Env,
+		From:    opts.From,
+		To:      opts.To,
+		Changes: changes,
+	})
+}
+
+func writeTable(out io.Writer, changes []diff.Change) error {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No differences found.")
+
+		return nil
+	}
+
+	table, err := cli.NewTable(
+		cli.WithHeaders{"KIND", "CHANGE", "NAME", "FROM", "TO"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, c := range changes {
+		table.WriteRow(cli.TableRow{
+			{Value: string(c.Kind)},
+			actionField(c.Action),
+			{Value: c.Name},
+			{Value: c.From},
+			{Value: c.To},
+		})
+	}
+
+	fmt.Fprintln(out, table.String())
+
+	return nil
+}
+
+func actionField(action diff.Action) cli.Field {
+	field := cli.Field{Value: string(action)}
+
+	switch action {
+	case diff.ActionAdded:
+		field.Color = cli.FieldColorGreen
+	case diff.ActionRemoved:
+		field.Color = cli.FieldColorIntenselyBoldRed
+	}
+
+	return field
+}
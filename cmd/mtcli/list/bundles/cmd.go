@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
 	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 
 	"github.com/spf13/cobra"
 )
@@ -17,32 +19,40 @@ func examples() string {
 }
 
 func Cmd() *cobra.Command {
-	return &cobra.Command{
+	opts := cli.NewCacheOptions()
+
+	cmd := &cobra.Command{
 		Use:     "bundles",
 		Short:   "List all the bundles present in an index image.",
 		Example: examples(),
 		Args:    cobra.ExactArgs(1),
-		RunE:    run,
+		RunE:    run(&opts),
 	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	indexImageURL := args[0]
+func run(opts *cli.CacheOptions) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		indexImageURL := args[0]
 
-	extractor := extractor.New()
-	allBundles, err := extractor.ExtractAllBundles(cmd.Context(), indexImageURL)
-	if err != nil {
-		return fmt.Errorf("extracting and parsing bundles from index image %q: %w", indexImageURL, err)
-	}
+		extractor := extractor.New(opts.ExtractorOptions(validator.NewRegistryClient())...)
+		allBundles, err := extractor.ExtractAllBundles(cmd.Context(), indexImageURL)
+		if err != nil {
+			return fmt.Errorf("extracting and parsing bundles from index image %q: %w", indexImageURL, err)
+		}
 
-	var operatorVersionedNames []string
-	for _, bundle := range allBundles {
-		csv := bundle.ClusterServiceVersion
+		var operatorVersionedNames []string
+		for _, bundle := range allBundles {
+			csv := bundle.ClusterServiceVersion
 
-		operatorVersionedNames = append(operatorVersionedNames, csv.Name)
-	}
+			operatorVersionedNames = append(operatorVersionedNames, csv.Name)
+		}
 
-	fmt.Fprintln(cmd.OutOrStdout(), strings.Join(operatorVersionedNames, "\n"))
+		fmt.Fprintln(cmd.OutOrStdout(), strings.Join(operatorVersionedNames, "\n"))
 
-	return nil
+		return nil
+	}
 }
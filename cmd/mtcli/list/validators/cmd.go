@@ -6,6 +6,7 @@ import (
 
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/plugin"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
 	"github.com/spf13/cobra"
 )
@@ -14,26 +15,52 @@ func examples() string {
 	return strings.Join([]string{
 		"  # List all the registered validators.",
 		"  mtcli list validators",
+		"  # List the registered validators and those provided by plugins.",
+		"  mtcli list validators --plugin-dir ./plugins",
 	}, "\n")
 }
 
 func Cmd() *cobra.Command {
-	return &cobra.Command{
+	var pluginDir string
+
+	cmd := &cobra.Command{
 		Use:     "validators",
 		Short:   "List all the registered validators.",
 		Example: examples(),
-		RunE:    run,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd, pluginDir)
+		},
 	}
+
+	cmd.Flags().StringVar(
+		&pluginDir,
+		"plugin-dir",
+		pluginDir,
+		"Also list the validators provided by the executables in the given directory.",
+	)
+
+	return cmd
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	runner, err := validator.NewRunner()
+func run(cmd *cobra.Command, pluginDir string) error {
+	var plugins []validator.Initializer
+
+	if pluginDir != "" {
+		var err error
+
+		plugins, err = plugin.Load(cmd.Context(), pluginDir)
+		if err != nil {
+			return fmt.Errorf("loading plugins: %w", err)
+		}
+	}
+
+	runner, err := validator.NewRunner(validator.WithAdditionalInitializers(plugins))
 	if err != nil {
 		return fmt.Errorf("listing validators: %s\n", err)
 	}
 
 	table, err := cli.NewTable(
-		cli.WithHeaders{"CODE", "NAME", "DESCRIPTION"},
+		cli.WithHeaders{"CODE", "NAME", "SEVERITY", "DESCRIPTION"},
 	)
 	if err != nil {
 		return fmt.Errorf("initializing table: %w", err)
@@ -43,6 +70,7 @@ func run(cmd *cobra.Command, args []string) error {
 		table.WriteRow(cli.TableRow{
 			cli.Field{Value: v.Code().String()},
 			cli.Field{Value: v.Name()},
+			cli.Field{Value: v.Severity().String()},
 			cli.Field{Value: v.Description()},
 		})
 	}
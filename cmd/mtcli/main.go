@@ -7,7 +7,9 @@ import (
 	"os/signal"
 
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/cache"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/completion"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/diff"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/list"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/validate"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/version"
@@ -30,7 +32,7 @@ func main() {
 	rootCmd := generateRootCmd()
 
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		fmt.Fprintln(os.Stdout, err)
+		fmt.Fprintln(os.Stderr, err)
 
 		code = 1
 	}
@@ -43,9 +45,12 @@ func generateRootCmd() *cobra.Command {
 	}
 
 	rootCmd.AddCommand(bundle.Cmd())
+	rootCmd.AddCommand(cache.Cmd())
 	rootCmd.AddCommand(completion.Cmd())
+	rootCmd.AddCommand(diff.Cmd())
 	rootCmd.AddCommand(list.Cmd())
 	rootCmd.AddCommand(validate.Cmd())
+	rootCmd.AddCommand(validate.RepoCmd())
 	rootCmd.AddCommand(version.Cmd())
 
 	flags := rootCmd.PersistentFlags()
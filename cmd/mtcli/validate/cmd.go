@@ -7,13 +7,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"sort"
 	"strings"
+	"time"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
-	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
-	"github.com/mt-sre/addon-metadata-operator/pkg/types"
-	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/mt-sre/addon-metadata-operator/internal/pipeline"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
 	"github.com/spf13/cobra"
@@ -33,12 +32,40 @@ func examples() string {
 		"  mtcli validate --env integration --disabled AM0001,AM0002 <path/to/addon_dir>",
 		"  # Validate an integration addon using imageset, enabled only 001_foo.",
 		"  mtcli validate --env integration --enabled AM0001 <path/to/addon_dir>",
+		"  # Validate a staging addon using the validation config file at the root of the repository.",
+		"  mtcli validate --env stage --config .mtcli.yaml <path/to/addon_dir>",
+		"  # Validate a staging addon, also failing on validators which only report warnings.",
+		"  mtcli validate --env stage --fail-on warning <path/to/addon_dir>",
+		"  # Validate a staging addon, also failing if any validator was skipped e.g. because the addon has no bundles.",
+		"  mtcli validate --env stage --fail-on-skip <path/to/addon_dir>",
+		"  # Validate a staging addon, accepting the failures listed in <path/to/addon_dir>/.mtcli-suppressions.yaml.",
+		"  mtcli validate --env stage <path/to/addon_dir>",
+		"  # Validate every imageset of an addon in every environment.",
+		"  mtcli validate --all-envs --all-versions <path/to/addon_dir>",
+		"  # Validate a staging addon and write the results as SARIF for code-scanning dashboards.",
+		"  mtcli validate --env stage --output sarif <path/to/addon_dir> > results.sarif",
+		"  # Validate a staging addon and write the results in the status schema of the AddonMetadata resource.",
+		"  mtcli validate --env stage --output status-yaml <path/to/addon_dir>",
+		"  # Validate a staging addon and show how long each validator took.",
+		"  mtcli validate --env stage --timings <path/to/addon_dir>",
+		"  # Validate a staging addon without registry access, reading its bundles from unpacked bundle directories.",
+		"  mtcli validate --env stage --bundles-dir <path/to/bundles> <path/to/addon_dir>",
+		"  # Validate a staging addon without registry access, reading its bundles from a file-based catalog.",
+		"  mtcli validate --env stage --catalog-dir <path/to/catalog> <path/to/addon_dir>",
+		"  # Validate a staging addon without network access, skipping validators which require OCM, a registry or its bundles.",
+		"  mtcli validate --env stage --offline <path/to/addon_dir>",
+		"  # Validate a staging addon without network access, reading its bundles from unpacked bundle directories.",
+		"  mtcli validate --env stage --offline --bundles-dir <path/to/bundles> <path/to/addon_dir>",
 	}, "\n")
 }
 
 func Cmd() *cobra.Command {
 	opts := &options{
-		Env: "stage",
+		Env:     "stage",
+		Output:  report.FormatTable,
+		FailOn:  validator.SeverityError,
+		Timeout: 2 * time.Minute,
+		Cache:   cli.NewCacheOptions(),
 	}
 
 	cmd := &cobra.Command{
@@ -59,19 +86,32 @@ func Cmd() *cobra.Command {
 	opts.AddDisabledFlag(flags)
 	opts.AddEnabledFlag(flags)
 	opts.AddExcludedNamespacesFlag(flags)
+	opts.AddOutputFlag(flags)
+	opts.AddFailOnFlag(flags)
+	opts.AddFailOnSkipFlag(flags)
+	opts.AddConfigFlag(flags)
+	opts.Cache.AddFlags(flags)
+	opts.AddAllEnvsFlag(flags)
+	opts.AddAllVersionsFlag(flags)
+	opts.AddBundlesDirFlag(flags)
+	opts.AddCatalogDirFlag(flags)
+	opts.AddOfflineFlag(flags)
+	opts.AddTimeoutFlag(flags)
+	opts.AddTimingsFlag(flags)
+	opts.AddPluginDirFlag(flags)
+	opts.AddRegistryAuthFileFlag(flags)
+
+	cmd.MarkFlagsMutuallyExclusive("env", "all-envs")
+	cmd.MarkFlagsMutuallyExclusive("version", "all-versions")
+	cmd.MarkFlagsMutuallyExclusive("bundles-dir", "catalog-dir")
 
 	return cmd
 }
 
-const (
-	ocmTokenEnvVar        = "OCM_TOKEN"
-	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
-	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
-)
-
 var (
 	ErrValidationFailed  = errors.New("validation failed")
 	ErrValidationErrored = errors.New("validators encountered errors")
+	ErrValidationSkipped = errors.New("validators were skipped")
 )
 
 func run(opts *options) func(cmd *cobra.Command, args []string) error {
@@ -83,6 +123,14 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("verifying flags: %w", err)
 		}
 
+		writer, err := report.NewWriter(opts.Output,
+			report.WithTimings(opts.Timings),
+			report.WithFailOn(opts.FailOn),
+		)
+		if err != nil {
+			return fmt.Errorf("initializing report writer: %w", err)
+		}
+
 		addonDir, err := parseAddonDir(args[0])
 		if err != nil {
 			return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
@@ -92,87 +140,45 @@ func run(opts *options) func(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("verifying addon dir %q: %w", addonDir, err)
 		}
 
-		meta, err := utils.NewMetaLoader(addonDir, opts.Env, opts.Version).Load()
-		if err != nil {
-			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
-		}
-
-		extractor := extractor.New()
-		bundles, err := extractor.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
-		if err != nil {
-			return fmt.Errorf("extracting and parsing addon bundles: %w", err)
-		}
-
-		filter, err := generateFilter(opts.Disabled, opts.Enabled)
+		targets, err := pipeline.ResolveTargets(addonDir, opts.Selection())
 		if err != nil {
-			return fmt.Errorf("generating validator filter: %w", err)
+			return fmt.Errorf("resolving validation targets: %w", err)
 		}
 
-		ocm, err := validator.NewOCMClient(
-			validator.WithConnectOptions{
-				validator.WithAPIURL(envToOCMURL(opts.Env)),
-				validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
-				validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
-				validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
-			},
-		)
+		pipe, err := opts.NewPipeline(ctx)
 		if err != nil {
-			return fmt.Errorf("initializing ocm client: %w", err)
+			return err
 		}
 
-		defer func() { _ = ocm.CloseConnection() }()
+		defer pipe.Close()
 
-		runner, err := validator.NewRunner(
-			validator.WithMiddleware{
-				validator.NewRetryMiddleware(),
-			},
-			validator.WithOCMClient{OCMClient: ocm},
-			validator.WithValidatorOptions{
-				validator.WithExcludedNamespaces(opts.ExcludedNamespaces),
-			},
-		)
+		suites, err := pipe.Validate(ctx, addonDir, targets...)
 		if err != nil {
-			return fmt.Errorf("initializing validators: %w", err)
-		}
-
-		mb := types.MetaBundle{
-			AddonMeta: meta,
-			Bundles:   bundles,
+			return err
 		}
 
-		var results validator.ResultList
+		rep := report.Report{Suites: suites}
 
-		for res := range runner.Run(ctx, mb, filter) {
-			results = append(results, res)
-		}
-
-		sort.Sort(results)
-
-		table, err := cli.NewTable(
-			cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
-		)
-		if err != nil {
-			return fmt.Errorf("initializing table: %w", err)
+		if err := writer.Write(cmd.OutOrStdout(), rep); err != nil {
+			return fmt.Errorf("writing report: %w", err)
 		}
-		for _, res := range results {
-			writeResult(table, res)
-		}
-
-		out := cmd.OutOrStdout()
 
-		fmt.Fprintln(out, table.String())
-		fmt.Fprintln(out)
-		fmt.Fprintln(out, "Please consult corresponding validator wikis: https://github.com/mt-sre/addon-metadata-operator/wiki/<code>.")
+		if errs := rep.Errors(); len(errs) > 0 {
+			if opts.Output == report.FormatTable {
+				cli.PrintValidationErrors(errs)
+			}
 
-		if errs := results.Errors(); len(errs) > 0 {
-			cli.PrintValidationErrors(errs)
 			return ErrValidationErrored
 		}
 
-		if results.HasFailure() {
+		if rep.HasFailureAtOrAbove(opts.FailOn) {
 			return ErrValidationFailed
 		}
 
+		if opts.FailOnSkip && rep.HasSkipped() {
+			return ErrValidationSkipped
+		}
+
 		return nil
 	}
 }
@@ -237,55 +243,3 @@ func parseCodeList(maybeList string) ([]validator.Code, error) {
 
 	return res, nil
 }
-
-func envToOCMURL(env string) string {
-	envToUrl := map[string]string{
-		"stage":       "https://api.stage.openshift.com",
-		"integration": "https://api.integration.openshift.com",
-		"production":  "https://api.openshift.com",
-	}
-
-	return envToUrl[env]
-}
-
-func writeResult(t *cli.Table, res validator.Result) {
-	row := resultToRow(res)
-
-	if res.IsSuccess() {
-		t.WriteRow(append(row, cli.Field{Value: "None"}))
-	} else if res.IsError() {
-		t.WriteRow(append(row, cli.Field{Value: res.Error.Error()}))
-	} else {
-		for _, msg := range res.FailureMsgs {
-			t.WriteRow(append(row, cli.Field{Value: msg}))
-		}
-	}
-}
-
-func resultToRow(res validator.Result) cli.TableRow {
-	var status cli.Field
-
-	if res.IsSuccess() {
-		status = cli.Field{
-			Value: "Success",
-			Color: cli.FieldColorGreen,
-		}
-	} else if res.IsError() {
-		status = cli.Field{
-			Value: "Error",
-			Color: cli.FieldColorIntenselyBoldRed,
-		}
-	} else {
-		status = cli.Field{
-			Value: "Failed",
-			Color: cli.FieldColorRed,
-		}
-	}
-
-	return cli.TableRow{
-		status,
-		cli.Field{Value: res.Code.String()},
-		cli.Field{Value: res.Name},
-		cli.Field{Value: res.Description},
-	}
-}
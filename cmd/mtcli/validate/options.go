@@ -1,9 +1,19 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/config"
+	"github.com/mt-sre/addon-metadata-operator/internal/pipeline"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/plugin"
 	"github.com/spf13/pflag"
 	"golang.org/x/mod/semver"
 )
@@ -14,6 +24,21 @@ type options struct {
 	Disabled           string
 	Enabled            string
 	ExcludedNamespaces []string
+	Output             report.Format
+	FailOn             validator.Severity
+	FailOnSkip         bool
+	Config             string
+	AllEnvs            bool
+	AllVersions        bool
+	Jobs               int
+	BundlesDir         string
+	CatalogDir         string
+	Offline            bool
+	Timeout            time.Duration
+	Timings            bool
+	PluginDir          string
+	RegistryAuthFile   string
+	Cache              cli.CacheOptions
 }
 
 func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
@@ -61,6 +86,130 @@ func (o *options) AddExcludedNamespacesFlag(flags *pflag.FlagSet) {
 	)
 }
 
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.Var(
+		(*formatValue)(&o.Output),
+		"output",
+		fmt.Sprintf("Output format of the validation results. One of: %s.", formatNames()),
+	)
+}
+
+func (o *options) AddFailOnFlag(flags *pflag.FlagSet) {
+	flags.Var(
+		(*severityValue)(&o.FailOn),
+		"fail-on",
+		"Minimum severity of failed validators which causes validation to fail. One of: info, warning, error.",
+	)
+}
+
+func (o *options) AddFailOnSkipFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.FailOnSkip,
+		"fail-on-skip",
+		o.FailOnSkip,
+		"Fail validation if any validator was skipped.",
+	)
+}
+
+func (o *options) AddConfigFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Config,
+		"config",
+		o.Config,
+		fmt.Sprintf("Path to a validation config file. Defaults to the first '%s' found in the addon directory or its parents up to the repository root. Flags take precedence over the config file.", config.FileName),
+	)
+}
+
+func (o *options) AddAllEnvsFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.AllEnvs,
+		"all-envs",
+		o.AllEnvs,
+		"Validate every environment found in the addon directory. Can't be combined with --env.",
+	)
+}
+
+func (o *options) AddAllVersionsFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.AllVersions,
+		"all-versions",
+		o.AllVersions,
+		"Validate every imageset of each environment. Can't be combined with --version.",
+	)
+}
+
+func (o *options) AddJobsFlag(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.Jobs,
+		"jobs",
+		o.Jobs,
+		"Number of addons validated concurrently.",
+	)
+}
+
+func (o *options) AddBundlesDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.BundlesDir,
+		"bundles-dir",
+		o.BundlesDir,
+		"Read unpacked bundles ('manifests' and 'metadata' directories) from the given directory instead of the index image. Can't be combined with --catalog-dir.",
+	)
+}
+
+func (o *options) AddCatalogDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CatalogDir,
+		"catalog-dir",
+		o.CatalogDir,
+		"Read bundles from the file-based catalog in the given directory instead of the index image. Can't be combined with --bundles-dir.",
+	)
+}
+
+func (o *options) AddOfflineFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Offline,
+		"offline",
+		o.Offline,
+		"Skip validators which require network access and report them as skipped. Validators requiring addon bundles are skipped as well unless --bundles-dir or --catalog-dir is given.",
+	)
+}
+
+func (o *options) AddTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.Timeout,
+		"validator-timeout",
+		o.Timeout,
+		"Deadline of each validator run. Validators exceeding it report an error. Timeouts of individual validators can be set in the config file.",
+	)
+}
+
+func (o *options) AddTimingsFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Timings,
+		"timings",
+		o.Timings,
+		"Append the duration of each validator run, including retries, to the results table.",
+	)
+}
+
+func (o *options) AddPluginDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.PluginDir,
+		"plugin-dir",
+		o.PluginDir,
+		"Load additional validators from the executables in the given directory.",
+	)
+}
+
+func (o *options) AddRegistryAuthFileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.RegistryAuthFile,
+		"registry-auth-file",
+		o.RegistryAuthFile,
+		"Authenticate with image registries using the credentials of the given docker 'config.json' or pull secret. Registries are accessed anonymously otherwise.",
+	)
+}
+
 func (o *options) VerifyFlags() error {
 	if !isValidEnv(o.Env) {
 		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
@@ -84,10 +233,130 @@ func (o *options) VerifyFlags() error {
 }
 
 func isValidEnv(env string) bool {
-	switch env {
-	case "stage", "integration", "production":
-		return true
+	return pipeline.IsValidEnv(env)
+}
+
+// formatValue implements pflag.Value for report.Format so that
+// unsupported formats are rejected while parsing flags.
+type formatValue report.Format
+
+func (f *formatValue) String() string { return string(*f) }
+
+func (f *formatValue) Set(s string) error {
+	format, err := report.ParseFormat(s)
+	if err != nil {
+		return err
+	}
+
+	*f = formatValue(format)
+
+	return nil
+}
+
+func (f *formatValue) Type() string { return "format" }
+
+func formatNames() string {
+	formats := report.Formats()
+	names := make([]string, 0, len(formats))
+
+	for _, f := range formats {
+		names = append(names, string(f))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// severityValue implements pflag.Value for validator.Severity.
+type severityValue validator.Severity
+
+func (s *severityValue) String() string { return validator.Severity(*s).String() }
+
+func (s *severityValue) Set(maybeSeverity string) error {
+	sev, err := validator.ParseSeverity(maybeSeverity)
+	if err != nil {
+		return err
+	}
+
+	*s = severityValue(sev)
+
+	return nil
+}
+
+func (s *severityValue) Type() string { return "severity" }
+
+// Selection returns the targets selected by the options.
+func (o *options) Selection() pipeline.Selection {
+	return pipeline.Selection{
+		Env:         o.Env,
+		Version:     o.Version,
+		AllEnvs:     o.AllEnvs,
+		AllVersions: o.AllVersions,
+	}
+}
+
+// NewPipeline returns a validation pipeline configured by the options.
+func (o *options) NewPipeline(ctx context.Context, opts ...pipeline.Option) (*pipeline.Pipeline, error) {
+	filter, err := generateFilter(o.Disabled, o.Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("generating validator filter: %w", err)
+	}
+
+	var plugins []validator.Initializer
+
+	if o.PluginDir != "" {
+		plugins, err = plugin.Load(ctx, o.PluginDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading plugins: %w", err)
+		}
+	}
+
+	var registryOpts []validator.RegistryClientOption
+
+	if o.RegistryAuthFile != "" {
+		creds, err := validator.LoadDockerConfig(o.RegistryAuthFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading registry credentials: %w", err)
+		}
+
+		registryOpts = append(registryOpts, creds)
+	}
+
+	registry := validator.NewRegistryClient(registryOpts...)
+
+	return pipeline.New(append([]pipeline.Option{
+		pipeline.WithExtractor{Extractor: o.extractor(registry)},
+		pipeline.WithConfigPath(o.Config),
+		pipeline.WithFilter(filter),
+		pipeline.WithExcludedNamespaces(o.ExcludedNamespaces),
+		pipeline.WithOffline(o.Offline),
+		pipeline.WithTimeout(o.Timeout),
+		pipeline.WithPlugins(plugins),
+		pipeline.WithRegistryClient{RegistryClient: registry},
+		pipeline.WithSkipBundles(o.Offline && !o.hasLocalBundles()),
+	}, opts...)...), nil
+}
+
+// hasLocalBundles returns 'true' if bundles are read from
+// a local directory rather than the index image.
+func (o *options) hasLocalBundles() bool {
+	return o.BundlesDir != "" || o.CatalogDir != ""
+}
+
+// extractor returns the extractor reading bundles from the local
+// directory given by the options or from the index image otherwise.
+// The given registry client resolves the digests of cached images.
+func (o *options) extractor(registry validator.RegistryClient) extractor.Extractor {
+	switch {
+	case o.BundlesDir != "":
+		return extractor.NewDirectoryExtractor(o.BundlesDir)
+	case o.CatalogDir != "":
+		catalog := extractor.NewCatalogExtractor(o.CatalogDir)
+
+		return extractor.New(
+			extractor.WithIndexExtractor(catalog),
+			extractor.WithBundleExtractor(catalog),
+		)
 	default:
-		return false
+		return extractor.New(o.Cache.ExtractorOptions(registry)...)
 	}
 }
@@ -0,0 +1,346 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/pipeline"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var errInvalidJobs = errors.New("'--jobs' must be at least 1")
+
+const repoLong = "Validate every addon found below a managed-tenants addons directory."
+
+func repoExamples() string {
+	return strings.Join([]string{
+		"  # Validate every staging addon in a managed-tenants checkout.",
+		"  mtcli validate-repo --env stage <path/to/managed-tenants/addons>",
+		"  # Validate every imageset of every addon in all environments using 8 workers.",
+		"  mtcli validate-repo --all-envs --all-versions --jobs 8 <path/to/managed-tenants/addons>",
+		"  # Validate every staging addon without network access.",
+		"  mtcli validate-repo --env stage --offline <path/to/managed-tenants/addons>",
+	}, "\n")
+}
+
+// RepoCmd returns the 'validate-repo' command which runs the
+// validation pipeline for every addon directory below a root
+// directory.
+func RepoCmd() *cobra.Command {
+	opts := &options{
+		Env:     "stage",
+		Output:  report.FormatTable,
+		FailOn:  validator.SeverityError,
+		Timeout: 2 * time.Minute,
+		Cache:   cli.NewCacheOptions(),
+		Jobs:    runtime.NumCPU(),
+	}
+
+	cmd := &cobra.Command{
+		Use:           "validate-repo",
+		Short:         "Validate all addons of a managed-tenants repository.",
+		Long:          repoLong,
+		Example:       repoExamples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          runRepo(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.PersistentFlags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+	opts.AddDisabledFlag(flags)
+	opts.AddEnabledFlag(flags)
+	opts.AddExcludedNamespacesFlag(flags)
+	opts.AddOutputFlag(flags)
+	opts.AddFailOnFlag(flags)
+	opts.AddFailOnSkipFlag(flags)
+	opts.AddConfigFlag(flags)
+	opts.Cache.AddFlags(flags)
+	opts.AddAllEnvsFlag(flags)
+	opts.AddAllVersionsFlag(flags)
+	opts.AddBundlesDirFlag(flags)
+	opts.AddCatalogDirFlag(flags)
+	opts.AddOfflineFlag(flags)
+	opts.AddTimeoutFlag(flags)
+	opts.AddTimingsFlag(flags)
+	opts.AddPluginDirFlag(flags)
+	opts.AddRegistryAuthFileFlag(flags)
+	opts.AddJobsFlag(flags)
+
+	cmd.MarkFlagsMutuallyExclusive("env", "all-envs")
+	cmd.MarkFlagsMutuallyExclusive("version", "all-versions")
+	cmd.MarkFlagsMutuallyExclusive("bundles-dir", "catalog-dir")
+
+	return cmd
+}
+
+func runRepo(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		if opts.Jobs < 1 {
+			return errInvalidJobs
+		}
+
+		writer, err := report.NewWriter(opts.Output,
+			report.WithTimings(opts.Timings),
+			report.WithFailOn(opts.FailOn),
+		)
+		if err != nil {
+			return fmt.Errorf("initializing report writer: %w", err)
+		}
+
+		root, err := parseAddonDir(args[0])
+		if err != nil {
+			return fmt.Errorf("parsing addons root %q: %w", args[0], err)
+		}
+
+		if err := verifyAddonDir(root); err != nil {
+			return fmt.Errorf("verifying addons root %q: %w", root, err)
+		}
+
+		addonDirs, err := pipeline.DiscoverAddons(root)
+		if err != nil {
+			return err
+		}
+
+		if len(addonDirs) == 0 {
+			return fmt.Errorf("no addons found in %q", root)
+		}
+
+		pipe, err := opts.NewPipeline(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer pipe.Close()
+
+		results := make([]addonResult, len(addonDirs))
+
+		var g errgroup.Group
+
+		g.SetLimit(opts.Jobs)
+
+		for i, dir := range addonDirs {
+			i, dir := i, dir
+
+			g.Go(func() error {
+				results[i] = validateAddon(ctx, pipe, root, dir, opts.Selection())
+
+				return nil
+			})
+		}
+
+		_ = g.Wait()
+
+		if err := writeRepoReport(cmd.OutOrStdout(), writer, opts, results); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+
+		var failed, errored, skipped bool
+
+		for _, res := range results {
+			switch res.Status(opts.FailOn, opts.FailOnSkip) {
+			case addonStatusErrored:
+				errored = true
+
+				if res.Err != nil && opts.Output != report.FormatTable {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", res.Name, res.Err)
+				}
+			case addonStatusFailed:
+				failed = true
+			case addonStatusSkipped:
+				skipped = true
+			}
+		}
+
+		if errored {
+			return ErrValidationErrored
+		}
+
+		if failed {
+			return ErrValidationFailed
+		}
+
+		if skipped {
+			return ErrValidationSkipped
+		}
+
+		return nil
+	}
+}
+
+func validateAddon(ctx context.Context, pipe *pipeline.Pipeline, root, dir string, sel pipeline.Selection) addonResult {
+	res := addonResult{
+		Name: dir,
+	}
+
+	if rel, err := filepath.Rel(root, dir); err == nil {
+		res.Name = rel
+	}
+
+	targets, err := pipeline.ResolveTargets(dir, sel)
+	if err != nil {
+		res.Err = fmt.Errorf("resolving validation targets: %w", err)
+
+		return res
+	}
+
+	res.Suites, res.Err = pipe.Validate(ctx, dir, targets...)
+
+	return res
+}
+
+// writeRepoReport writes the combined report of all addons. In table
+// format only the addons which did not pass are rendered in detail
+// followed by a summary of every addon.
+func writeRepoReport(out io.Writer, writer report.Writer, opts *options, results []addonResult) error {
+	var rep report.Report
+
+	for _, res := range results {
+		if opts.Output == report.FormatTable && res.Status(opts.FailOn, opts.FailOnSkip) == addonStatusPassed {
+			continue
+		}
+
+		rep.Suites = append(rep.Suites, res.Suites...)
+	}
+
+	if opts.Output != report.FormatTable {
+		return writer.Write(out, rep)
+	}
+
+	if len(rep.Suites) > 0 {
+		if err := writer.Write(out, rep); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out)
+	}
+
+	table, err := cli.NewTable(
+		cli.WithHeaders{"ADDON", "STATUS", "TARGETS", "FAILED", "ERRORS", "SUPPRESSED", "SKIPPED", "MESSAGE"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, res := range results {
+		table.WriteRow(res.ToRow(opts.FailOn, opts.FailOnSkip))
+	}
+
+	fmt.Fprintln(out, table.String())
+
+	return nil
+}
+
+type addonStatus string
+
+const (
+	addonStatusPassed  addonStatus = "Passed"
+	addonStatusFailed  addonStatus = "Failed"
+	addonStatusErrored addonStatus = "Errored"
+	addonStatusSkipped addonStatus = "Skipped"
+)
+
+// addonResult holds the outcome of validating a single addon
+// directory.
+type addonResult struct {
+	// Name is the path of the addon directory relative to
+	// the addons root.
+	Name   string
+	Suites []report.Suite
+	// Err is set if the addon could not be validated.
+	Err error
+}
+
+// Status returns the status of the addon where validator errors and
+// pipeline errors are reported as errored while failures at or above
+// the given severity are reported as failed. Skipped validators are
+// reported as skipped if failOnSkip is set.
+func (r addonResult) Status(failOn validator.Severity, failOnSkip bool) addonStatus {
+	rep := report.Report{Suites: r.Suites}
+
+	switch {
+	case r.Err != nil || len(rep.Errors()) > 0:
+		return addonStatusErrored
+	case rep.HasFailureAtOrAbove(failOn):
+		return addonStatusFailed
+	case failOnSkip && rep.HasSkipped():
+		return addonStatusSkipped
+	default:
+		return addonStatusPassed
+	}
+}
+
+func (r addonResult) ToRow(failOn validator.Severity, failOnSkip bool) cli.TableRow {
+	var failed, errored, suppressed, skipped int
+
+	for _, s := range r.Suites {
+		if s.Err != nil {
+			errored++
+		}
+
+		for _, res := range s.Results {
+			switch {
+			case res.IsSuccess():
+			case res.IsSkipped():
+				skipped++
+			case res.IsError():
+				errored++
+			case res.IsSuppressed():
+				suppressed++
+			default:
+				failed++
+			}
+		}
+	}
+
+	status := r.Status(failOn, failOnSkip)
+
+	var color cli.FieldColor
+
+	switch status {
+	case addonStatusPassed:
+		color = cli.FieldColorGreen
+	case addonStatusFailed, addonStatusSkipped:
+		color = cli.FieldColorRed
+	case addonStatusErrored:
+		color = cli.FieldColorIntenselyBoldRed
+	}
+
+	var msg string
+
+	if r.Err != nil {
+		msg = r.Err.Error()
+	}
+
+	return cli.TableRow{
+		cli.Field{Value: r.Name},
+		cli.Field{Value: string(status), Color: color},
+		cli.Field{Value: fmt.Sprint(len(r.Suites))},
+		cli.Field{Value: fmt.Sprint(failed)},
+		cli.Field{Value: fmt.Sprint(errored)},
+		cli.Field{Value: fmt.Sprint(suppressed)},
+		cli.Field{Value: fmt.Sprint(skipped)},
+		cli.Field{Value: msg},
+	}
+}
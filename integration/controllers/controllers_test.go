@@ -0,0 +1,189 @@
+//go:build !unit
+// +build !unit
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AddonMetadata controller", func() {
+	var namespace string
+
+	BeforeEach(func(ctx context.Context) {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "addons-"},
+		}
+		Expect(_client.Create(ctx, ns)).To(Succeed())
+
+		namespace = ns.Name
+	})
+
+	It("resolves the referenced imageset and reports validation results", func(ctx context.Context) {
+		addon := &v1alpha1.AddonMetadata{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "reference-addon"},
+			Spec:       referenceAddonSpec("latest"),
+		}
+		Expect(_client.Create(ctx, addon)).To(Succeed())
+
+		By("waiting for the imageset")
+		Eventually(condition(ctx, addon, v1alpha1.ConditionImageSetResolved)).
+			WithTimeout(30 * time.Second).
+			Should(HaveField("Reason", v1alpha1.ReasonImageSetNotFound))
+
+		By("creating a valid imageset")
+		Expect(_client.Create(ctx, imageSet(namespace, "reference-addon.v0.1.0", "quay.io/osd-addons/reference-addon-index@sha256:good"))).To(Succeed())
+
+		Eventually(condition(ctx, addon, v1alpha1.ConditionValidated)).
+			WithTimeout(30 * time.Second).
+			Should(HaveField("Status", metav1.ConditionTrue))
+
+		By("creating a newer invalid imageset")
+		Expect(_client.Create(ctx, imageSet(namespace, "reference-addon.v0.2.0", "quay.io/osd-addons/reference-addon-index@sha256:bad"))).To(Succeed())
+
+		Eventually(condition(ctx, addon, v1alpha1.ConditionValidated)).
+			WithTimeout(30 * time.Second).
+			Should(And(
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", v1alpha1.ReasonValidationFailed),
+			))
+	})
+})
+
+var _ = Describe("AddonImageSet controller", func() {
+	var namespace string
+
+	BeforeEach(func(ctx context.Context) {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "addons-"},
+		}
+		Expect(_client.Create(ctx, ns)).To(Succeed())
+
+		namespace = ns.Name
+	})
+
+	It("reports missing AddonMetadata", func(ctx context.Context) {
+		is := imageSet(namespace, "reference-addon.v0.1.0", "quay.io/osd-addons/reference-addon-index@sha256:good")
+		Expect(_client.Create(ctx, is)).To(Succeed())
+
+		Eventually(condition(ctx, is, v1alpha1.ConditionValidated)).
+			WithTimeout(30 * time.Second).
+			Should(And(
+				HaveField("Status", metav1.ConditionUnknown),
+				HaveField("Reason", v1alpha1.ReasonAddonMetadataNotFound),
+			))
+	})
+
+	It("validates the imageset against its AddonMetadata", func(ctx context.Context) {
+		addon := &v1alpha1.AddonMetadata{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "reference-addon"},
+			Spec:       referenceAddonSpec("0.1.0"),
+		}
+		Expect(_client.Create(ctx, addon)).To(Succeed())
+
+		is := imageSet(namespace, "reference-addon.v0.1.0", "quay.io/osd-addons/reference-addon-index@sha256:bad")
+		Expect(_client.Create(ctx, is)).To(Succeed())
+
+		Eventually(condition(ctx, is, v1alpha1.ConditionValidated)).
+			WithTimeout(30 * time.Second).
+			Should(HaveField("Status", metav1.ConditionFalse))
+	})
+})
+
+func referenceAddonSpec(imageSetVersion string) v1alpha1.AddonMetadataSpec {
+	return v1alpha1.AddonMetadataSpec{
+		ID:                   "reference-addon",
+		Name:                 "Reference Addon",
+		Description:          "An addon used for testing.",
+		Icon:                 "aWNvbg==",
+		Label:                "api.openshift.com/addon-reference-addon",
+		Enabled:              true,
+		AddonOwner:           "Addons Team <addons@redhat.com>",
+		QuayRepo:             "quay.io/osd-addons/reference-addon",
+		TestHarness:          "quay.io/osd-addons/reference-addon-test-harness",
+		InstallMode:          "OwnNamespace",
+		TargetNamespace:      "reference-addon",
+		Namespaces:           []string{"reference-addon"},
+		NamespaceLabels:      map[string]string{},
+		NamespaceAnnotations: map[string]string{},
+		OcmQuotaName:         "addon-reference-addon",
+		OcmQuotaCost:         0,
+		OperatorName:         "reference-addon",
+		DefaultChannel:       "alpha",
+		Channels:             &[]v1alpha1.Channel{{Name: "alpha", CurrentCSV: "reference-addon.v0.1.0"}},
+		ImageSetVersion:      &imageSetVersion,
+	}
+}
+
+func imageSet(namespace, name, indexImage string) *v1alpha1.AddonImageSet {
+	return &v1alpha1.AddonImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      strings.ReplaceAll(name, ".v", "-v"),
+		},
+		Spec: v1alpha1.AddonImageSetSpec{
+			Name:           name,
+			IndexImage:     indexImage,
+			RelatedImages:  []string{},
+			PullSecretName: "addon-pull-secret",
+		},
+	}
+}
+
+// condition returns a function polling the given condition
+// of the given object for use with 'Eventually'.
+func condition(ctx context.Context, obj client.Object, condType string) func() *metav1.Condition {
+	return func() *metav1.Condition {
+		if err := _client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return nil
+		}
+
+		var conds []metav1.Condition
+
+		switch o := obj.(type) {
+		case *v1alpha1.AddonMetadata:
+			conds = o.Status.Conditions
+		case *v1alpha1.AddonImageSet:
+			conds = o.Status.Conditions
+		}
+
+		return apimeta.FindStatusCondition(conds, condType)
+	}
+}
+
+// stubValidator fails metadata whose indexImage
+// ends with "bad" and passes all other metadata.
+type stubValidator struct {
+	mu sync.Mutex
+}
+
+func (v *stubValidator) Validate(_ context.Context, meta *v1alpha1.AddonMetadataSpec, _ *v1alpha1.AddonImageSetSpec) (types.MetaBundle, validator.ResultList, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	mb := types.MetaBundle{AddonMeta: meta}
+
+	base, err := validator.NewBase(1, validator.BaseName("stub"))
+	if err != nil {
+		return mb, nil, err
+	}
+
+	if strings.HasSuffix(*meta.IndexImage, "bad") {
+		return mb, validator.ResultList{base.Fail("index image is bad")}, nil
+	}
+
+	return mb, validator.ResultList{base.Success()}, nil
+}
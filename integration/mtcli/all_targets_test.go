@@ -0,0 +1,53 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand", func() {
+	It("validates every environment and imageset", func() {
+		metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+		cmd := exec.Command(_binPath, "validate", "--all-envs", "--all-versions", "--enabled", "AM0002", "--output", "json", metadataPath)
+		cmd.Env = []string{
+			`OCM_TOKEN=""`,
+		}
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit(0))
+
+		var doc struct {
+			Suites []struct {
+				Name string `json:"name"`
+			} `json:"suites"`
+		}
+
+		Expect(json.Unmarshal(session.Out.Contents(), &doc)).To(Succeed())
+		Expect(doc.Suites).To(HaveLen(3))
+		Expect(doc.Suites[0].Name).To(Equal("reference-addon (stage, 0.0.1)"))
+		Expect(doc.Suites[2].Name).To(Equal("reference-addon (stage, 0.0.5)"))
+	})
+
+	It("rejects --all-envs together with --env", func() {
+		metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+		cmd := exec.Command(_binPath, "validate", "--all-envs", "--env", "stage", metadataPath)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+	})
+})
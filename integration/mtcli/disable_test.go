@@ -19,9 +19,9 @@ var _ = Describe("validate subcommand", func() {
 		ShouldSucceed bool
 	}
 
-	DescribeTable("AM0002, AM0004, AM0011, AM0015 disabled",
+	DescribeTable("AM0002, AM0004, AM0011, AM0015, AM0019 disabled",
 		func(tc disableTestCase) {
-			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--disabled", "AM0002,AM0004,AM0011,AM0015", tc.MetadataPath)
+			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--disabled", "AM0002,AM0004,AM0011,AM0015,AM0019", tc.MetadataPath)
 			cmd.Env = []string{
 				`OCM_TOKEN=""`,
 			}
@@ -0,0 +1,43 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand with local bundles", func() {
+	metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	DescribeTable("validates bundles without registry access",
+		func(flag, dir string) {
+			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--enabled", "AM0003", "--no-cache", flag, dir, metadataPath)
+			cmd.Env = []string{
+				`OCM_TOKEN=""`,
+			}
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "60s").Should(Exit(0))
+		},
+		Entry("from bundle directories", "--bundles-dir", testutils.RootDir().TestData().Bundles()),
+		Entry("from a file-based catalog", "--catalog-dir", filepath.Join(testutils.RootDir().TestData().Catalogs(), "reference-addon")),
+	)
+
+	It("rejects --bundles-dir together with --catalog-dir", func() {
+		cmd := exec.Command(_binPath, "validate", "--env", "stage", "--bundles-dir", "a", "--catalog-dir", "b", metadataPath)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+	})
+})
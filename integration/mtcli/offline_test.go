@@ -0,0 +1,92 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate subcommand in offline mode", func() {
+	metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	type jsonReport struct {
+		Suites []struct {
+			Results []struct {
+				Code       string `json:"code"`
+				Status     string `json:"status"`
+				SkipReason string `json:"skipReason"`
+			} `json:"results"`
+		} `json:"suites"`
+	}
+
+	statuses := func(data []byte) map[string]string {
+		var rep jsonReport
+
+		Expect(json.Unmarshal(data, &rep)).To(Succeed())
+		Expect(rep.Suites).To(HaveLen(1))
+
+		res := make(map[string]string)
+
+		for _, r := range rep.Suites[0].Results {
+			res[r.Code] = r.Status
+		}
+
+		return res
+	}
+
+	It("skips validators requiring network access or bundles", func() {
+		cmd := exec.Command(_binPath, "validate", "--env", "stage", "--offline",
+			"--enabled", "AM0002,AM0003,AM0005,AM0011", "--output", "json", metadataPath,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+
+		Expect(statuses(session.Out.Contents())).To(Equal(map[string]string{
+			"AM0002": "success",
+			"AM0003": "skipped",
+			"AM0005": "skipped",
+			"AM0011": "skipped",
+		}))
+	})
+
+	It("runs validators requiring bundles from local bundles", func() {
+		cmd := exec.Command(_binPath, "validate", "--env", "stage", "--offline",
+			"--enabled", "AM0003,AM0011", "--output", "json",
+			"--bundles-dir", testutils.RootDir().TestData().Bundles(), metadataPath,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+
+		Expect(statuses(session.Out.Contents())).To(Equal(map[string]string{
+			"AM0003": "success",
+			"AM0011": "skipped",
+		}))
+	})
+
+	It("fails on skipped validators with --fail-on-skip", func() {
+		cmd := exec.Command(_binPath, "validate", "--env", "stage", "--offline", "--fail-on-skip",
+			"--enabled", "AM0002,AM0003", metadataPath,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("validators were skipped"))
+	})
+})
@@ -0,0 +1,69 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("validate subcommand", func() {
+	type outputTestCase struct {
+		Format    string
+		Unmarshal func([]byte, interface{}) error
+		Target    interface{}
+	}
+
+	DescribeTable("machine-readable output",
+		func(tc outputTestCase) {
+			metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+			cmd := exec.Command(_binPath, "validate", "--env", "stage", "--enabled", "AM0002", "--output", tc.Format, metadataPath)
+			cmd.Env = []string{
+				`OCM_TOKEN=""`,
+			}
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(0))
+
+			Expect(tc.Unmarshal(session.Out.Contents(), tc.Target)).To(Succeed())
+		},
+		Entry("json", outputTestCase{
+			Format:    "json",
+			Unmarshal: json.Unmarshal,
+			Target:    &map[string]interface{}{},
+		}),
+		Entry("junit", outputTestCase{
+			Format:    "junit",
+			Unmarshal: xml.Unmarshal,
+			Target: &struct {
+				XMLName xml.Name `xml:"testsuites"`
+			}{},
+		}),
+		Entry("sarif", outputTestCase{
+			Format:    "sarif",
+			Unmarshal: json.Unmarshal,
+			Target:    &map[string]interface{}{},
+		}),
+		Entry("status-yaml", outputTestCase{
+			Format:    "status-yaml",
+			Unmarshal: func(data []byte, v interface{}) error { return yaml.UnmarshalStrict(data, v) },
+			Target: &struct {
+				Name   string                    `json:"name"`
+				Status v1alpha1.ValidationStatus `json:"status"`
+			}{},
+		}),
+	)
+})
@@ -0,0 +1,61 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+const ownerLabelPlugin = `#!/bin/sh
+case "$1" in
+describe)
+	echo '{"apiVersion": "v1", "code": "AM1001", "name": "owner_label", "description": "Validate the owner label."}'
+	;;
+validate)
+	echo '{"failureMsgs": ["missing owner label"]}'
+	;;
+esac
+`
+
+var _ = Describe("validator plugins", func() {
+	metadataPath := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	var pluginDir string
+
+	BeforeEach(func() {
+		pluginDir = GinkgoT().TempDir()
+
+		Expect(os.WriteFile(filepath.Join(pluginDir, "owner-label"), []byte(ownerLabelPlugin), 0o755)).To(Succeed())
+	})
+
+	It("lists plugin validators", func() {
+		cmd := exec.Command(_binPath, "list", "validators", "--plugin-dir", pluginDir)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM1001"))
+	})
+
+	It("runs plugin validators", func() {
+		cmd := exec.Command(_binPath, "validate", "--env", "stage", "--offline",
+			"--enabled", "AM0002,AM1001", "--plugin-dir", pluginDir, metadataPath,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("missing owner label"))
+	})
+})
@@ -0,0 +1,44 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate-repo subcommand", func() {
+	It("validates every discovered addon", func() {
+		cmd := exec.Command(_binPath, "validate-repo", "--env", "stage", "--enabled", "AM0002", "--jobs", "2",
+			testutils.RootDir().TestData().MetadataV1().ImageSets(),
+		)
+		cmd.Env = []string{
+			`OCM_TOKEN=""`,
+		}
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit(0))
+
+		Expect(session.Out).To(Say("reference-addon"))
+		Expect(session.Out).To(Say("Passed"))
+	})
+
+	It("rejects fewer than one job", func() {
+		cmd := exec.Command(_binPath, "validate-repo", "--jobs", "0",
+			testutils.RootDir().TestData().MetadataV1().ImageSets(),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+	})
+})
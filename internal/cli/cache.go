@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	imageparser "github.com/novln/docker-parser"
+	"github.com/spf13/pflag"
+)
+
+const (
+	defaultCacheTTL       = 24 * time.Hour
+	defaultCacheMaxSizeMB = 1024
+)
+
+// NewCacheOptions returns CacheOptions populated with defaults.
+func NewCacheOptions() CacheOptions {
+	return CacheOptions{
+		Dir:       DefaultCacheDir(),
+		TTL:       defaultCacheTTL,
+		MaxSizeMB: defaultCacheMaxSizeMB,
+	}
+}
+
+// CacheOptions configures the on-disk cache of extracted bundles
+// and index listings shared between mtcli invocations.
+type CacheOptions struct {
+	Dir       string
+	Disabled  bool
+	TTL       time.Duration
+	MaxSizeMB int64
+}
+
+// DefaultCacheDir returns the 'mtcli' directory inside the user's
+// cache directory or inside the temporary directory if the former
+// cannot be determined.
+func DefaultCacheDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+
+	return filepath.Join(base, "mtcli")
+}
+
+func (o *CacheOptions) AddCacheDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Dir,
+		"cache-dir",
+		o.Dir,
+		"Directory in which extracted bundles and index listings are cached.",
+	)
+}
+
+// AddFlags adds all flags which control the use of the cache.
+func (o *CacheOptions) AddFlags(flags *pflag.FlagSet) {
+	o.AddCacheDirFlag(flags)
+
+	flags.BoolVar(
+		&o.Disabled,
+		"no-cache",
+		o.Disabled,
+		"Disable the on-disk cache of extracted bundles and index listings.",
+	)
+
+	flags.DurationVar(
+		&o.TTL,
+		"cache-ttl",
+		o.TTL,
+		"Maximum age of cached index listings and bundles whose tag could not be resolved to a digest. Entries keyed by digest never expire.",
+	)
+
+	flags.Int64Var(
+		&o.MaxSizeMB,
+		"cache-max-size",
+		o.MaxSizeMB,
+		"Maximum size of the cache in MiB. Least recently used entries are evicted first.",
+	)
+}
+
+// Stores returns the stores of the cache directory.
+func (o *CacheOptions) Stores() (bundles, indexes *extractor.DiskStore) {
+	return extractor.NewDiskCacheStores(o.Dir, o.storeOptions()...)
+}
+
+// ExtractorOptions returns the options which configure an extractor
+// to use the cache. Index images referenced by tag are resolved to
+// their current digest using the given registry client so that
+// listings of moved tags are not reused. No options are returned if
+// the cache is disabled.
+func (o *CacheOptions) ExtractorOptions(registry validator.RegistryClient) []extractor.MainExtractorOpt {
+	if o.Disabled {
+		return nil
+	}
+
+	return []extractor.MainExtractorOpt{
+		extractor.WithDiskCache(o.Dir, o.storeOptions()...),
+		extractor.WithDigestResolver(registryResolver{registry: registry}),
+	}
+}
+
+// registryResolver resolves image digests using a RegistryClient.
+type registryResolver struct {
+	registry validator.RegistryClient
+}
+
+func (r registryResolver) ResolveDigest(ctx context.Context, img string) (string, error) {
+	ref, err := imageparser.Parse(img)
+	if err != nil {
+		return "", fmt.Errorf("parsing image %q: %w", img, err)
+	}
+
+	return r.registry.ResolveDigest(ctx, ref)
+}
+
+func (o *CacheOptions) storeOptions() []extractor.DiskStoreOption {
+	const mib = 1 << 20
+
+	return []extractor.DiskStoreOption{
+		extractor.WithTTL(o.TTL),
+		// the size limit is split evenly between the stores
+		extractor.WithMaxSize(o.MaxSizeMB * mib / 2),
+	}
+}
@@ -105,6 +105,8 @@ func (fc FieldColor) Apply(s string) string {
 		return red(s)
 	case FieldColorIntenselyBoldRed:
 		return intenselyBoldRed(s)
+	case FieldColorYellow:
+		return yellow(s)
 	default:
 		return s
 	}
@@ -114,12 +116,14 @@ const (
 	FieldColorGreen            FieldColor = "green"
 	FieldColorRed              FieldColor = "red"
 	FieldColorIntenselyBoldRed FieldColor = "intenselyBoldRed"
+	FieldColorYellow           FieldColor = "yellow"
 )
 
 var (
 	green            = color.New(color.FgGreen).SprintFunc()
 	red              = color.New(color.FgRed).SprintFunc()
 	intenselyBoldRed = color.New(color.Bold, color.FgHiRed).SprintFunc()
+	yellow           = color.New(color.FgYellow).SprintFunc()
 )
 
 type TableConfig struct {
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"sigs.k8s.io/yaml"
+)
+
+// FileName is the name of the validation config file which is
+// discovered from an addon directory or any of its parents up to
+// the repository root.
+const FileName = ".mtcli.yaml"
+
+// Config is the repository-level validation config.
+//
+// Example:
+//
+//	disabled:
+//	- AM0011
+//	severities:
+//	  AM0015: info
+//	timeouts:
+//	  AM0005: 30s
+//	excludedNamespaces:
+//	- openshift-monitoring
+//	addons:
+//	  reference-addon:
+//	    disabled:
+//	    - AM0005
+type Config struct {
+	// Disabled lists validator codes which will not be run.
+	Disabled []string `json:"disabled,omitempty"`
+	// Severities overrides the severity of validators by code.
+	Severities map[string]string `json:"severities,omitempty"`
+	// Timeouts overrides the deadline of validators by code.
+	Timeouts map[string]string `json:"timeouts,omitempty"`
+	// ExcludedNamespaces lists namespaces excluded from validation.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	// Addons holds exceptions for individual addons keyed by
+	// addon name. Exceptions are merged on top of the
+	// repository-wide settings.
+	Addons map[string]AddonConfig `json:"addons,omitempty"`
+}
+
+// AddonConfig holds validation exceptions for a single addon.
+type AddonConfig struct {
+	Disabled           []string          `json:"disabled,omitempty"`
+	Severities         map[string]string `json:"severities,omitempty"`
+	Timeouts           map[string]string `json:"timeouts,omitempty"`
+	ExcludedNamespaces []string          `json:"excludedNamespaces,omitempty"`
+}
+
+// Settings are the parsed settings which apply to a single addon.
+type Settings struct {
+	Disabled           []validator.Code
+	Severities         map[validator.Code]validator.Severity
+	Timeouts           map[validator.Code]time.Duration
+	ExcludedNamespaces []string
+}
+
+// ForAddon merges the repository-wide settings with the exceptions
+// for the named addon. Codes, severities and timeouts are parsed and
+// an error is returned if any of them are invalid.
+func (c Config) ForAddon(name string) (Settings, error) {
+	disabled := append([]string{}, c.Disabled...)
+	excluded := append([]string{}, c.ExcludedNamespaces...)
+	severities := make(map[string]string, len(c.Severities))
+
+	timeouts := make(map[string]string, len(c.Timeouts))
+
+	for code, sev := range c.Severities {
+		severities[code] = sev
+	}
+
+	for code, timeout := range c.Timeouts {
+		timeouts[code] = timeout
+	}
+
+	if addon, ok := c.Addons[name]; ok {
+		disabled = append(disabled, addon.Disabled...)
+		excluded = append(excluded, addon.ExcludedNamespaces...)
+
+		for code, sev := range addon.Severities {
+			severities[code] = sev
+		}
+
+		for code, timeout := range addon.Timeouts {
+			timeouts[code] = timeout
+		}
+	}
+
+	settings := Settings{
+		ExcludedNamespaces: excluded,
+		Severities:         make(map[validator.Code]validator.Severity, len(severities)),
+		Timeouts:           make(map[validator.Code]time.Duration, len(timeouts)),
+	}
+
+	for _, raw := range disabled {
+		code, err := validator.ParseCode(raw)
+		if err != nil {
+			return Settings{}, fmt.Errorf("parsing disabled code: %w", err)
+		}
+
+		settings.Disabled = append(settings.Disabled, code)
+	}
+
+	for rawCode, rawSev := range severities {
+		code, err := validator.ParseCode(rawCode)
+		if err != nil {
+			return Settings{}, fmt.Errorf("parsing severity override code: %w", err)
+		}
+
+		sev, err := validator.ParseSeverity(rawSev)
+		if err != nil {
+			return Settings{}, fmt.Errorf("parsing severity override for %s: %w", code, err)
+		}
+
+		settings.Severities[code] = sev
+	}
+
+	for rawCode, rawTimeout := range timeouts {
+		code, err := validator.ParseCode(rawCode)
+		if err != nil {
+			return Settings{}, fmt.Errorf("parsing timeout code: %w", err)
+		}
+
+		timeout, err := time.ParseDuration(rawTimeout)
+		if err != nil {
+			return Settings{}, fmt.Errorf("parsing timeout for %s: %w", code, err)
+		}
+
+		settings.Timeouts[code] = timeout
+	}
+
+	return settings, nil
+}
+
+// Load reads and parses the config file at the given path.
+// Unknown fields are rejected to surface typos early.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var cfg Config
+
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Discover searches for a config file starting in the given directory
+// and moving up through its parents. The search stops after the first
+// directory containing a '.git' entry, which is treated as the
+// repository root. 'ok' is false if no config file was found.
+func Discover(dir string) (path string, ok bool, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", false, fmt.Errorf("resolving absolute path of %q: %w", dir, err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, FileName)
+
+		found, err := exists(candidate)
+		if err != nil {
+			return "", false, err
+		}
+
+		if found {
+			return candidate, true, nil
+		}
+
+		isRoot, err := exists(filepath.Join(dir, ".git"))
+		if err != nil {
+			return "", false, err
+		}
+
+		parent := filepath.Dir(dir)
+		if isRoot || parent == dir {
+			return "", false, nil
+		}
+
+		dir = parent
+	}
+}
+
+func exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("checking for %q: %w", path, err)
+}
+
+// SuppressionsFileName is the name of the optional sidecar file in
+// an addon directory which lists accepted validator failures.
+const SuppressionsFileName = ".mtcli-suppressions.yaml"
+
+// LoadSuppressions reads the suppressions sidecar file from the given
+// addon directory. No suppressions are returned if the file does
+// not exist.
+func LoadSuppressions(addonDir string) ([]validator.Suppression, error) {
+	path := filepath.Join(addonDir, SuppressionsFileName)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading suppressions file: %w", err)
+	}
+
+	sups, err := validator.ParseSuppressions(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing suppressions file %q: %w", path, err)
+	}
+
+	return sups, nil
+}
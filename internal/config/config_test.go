@@ -0,0 +1,197 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+disabled:
+- AM0011
+severities:
+  AM0015: info
+timeouts:
+  AM0005: 30s
+excludedNamespaces:
+- openshift-monitoring
+addons:
+  reference-addon:
+    disabled:
+    - AM0005
+    severities:
+      AM0015: warning
+    timeouts:
+      AM0011: 1m
+    excludedNamespaces:
+    - reference-addon-extra
+`
+
+func TestConfigForAddon(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, t.TempDir(), testConfig)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Addon    string
+		Expected Settings
+	}{
+		"without exceptions": {
+			Addon: "other-addon",
+			Expected: Settings{
+				Disabled: []validator.Code{11},
+				Severities: map[validator.Code]validator.Severity{
+					15: validator.SeverityInfo,
+				},
+				Timeouts: map[validator.Code]time.Duration{
+					5: 30 * time.Second,
+				},
+				ExcludedNamespaces: []string{"openshift-monitoring"},
+			},
+		},
+		"with exceptions": {
+			Addon: "reference-addon",
+			Expected: Settings{
+				Disabled: []validator.Code{11, 5},
+				Severities: map[validator.Code]validator.Severity{
+					15: validator.SeverityWarning,
+				},
+				Timeouts: map[validator.Code]time.Duration{
+					5:  30 * time.Second,
+					11: time.Minute,
+				},
+				ExcludedNamespaces: []string{"openshift-monitoring", "reference-addon-extra"},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			settings, err := cfg.ForAddon(tc.Addon)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, settings)
+		})
+	}
+}
+
+func TestConfigForAddonInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, cfg := range map[string]Config{
+		"invalid disabled code": {
+			Disabled: []string{"XX0001"},
+		},
+		"invalid severity code": {
+			Severities: map[string]string{"AM01": "info"},
+		},
+		"invalid timeout code": {
+			Timeouts: map[string]string{"AM01": "30s"},
+		},
+		"invalid timeout": {
+			Addons: map[string]AddonConfig{
+				"reference-addon": {
+					Timeouts: map[string]string{"AM0001": "soon"},
+				},
+			},
+		},
+		"invalid severity": {
+			Addons: map[string]AddonConfig{
+				"reference-addon": {
+					Severities: map[string]string{"AM0001": "critical"},
+				},
+			},
+		},
+	} {
+		cfg := cfg
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := cfg.ForAddon("reference-addon")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadRejectsUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, t.TempDir(), "disable:\n- AM0001\n")
+
+	_, err := Load(path)
+	assert.Error(t, err)
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o755))
+
+	addonDir := filepath.Join(root, "addons", "reference-addon")
+	require.NoError(t, os.MkdirAll(addonDir, 0o755))
+
+	_, ok, err := Discover(addonDir)
+	require.NoError(t, err)
+	assert.False(t, ok, "discovery must stop at the repository root")
+
+	rootConfig := writeConfig(t, root, testConfig)
+
+	path, ok, err := Discover(addonDir)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, rootConfig, path)
+
+	addonConfig := writeConfig(t, addonDir, testConfig)
+
+	path, ok, err = Discover(addonDir)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, addonConfig, path, "config in the addon directory takes precedence")
+}
+
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, FileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestLoadSuppressions(t *testing.T) {
+	t.Parallel()
+
+	addonDir := t.TempDir()
+
+	sups, err := LoadSuppressions(addonDir)
+	require.NoError(t, err)
+	assert.Empty(t, sups, "a missing file yields no suppressions")
+
+	content := `
+suppressions:
+- code: AM0012
+  message: "Wild card"
+  justification: "Manages arbitrary CRDs."
+  expires: "2030-01-01"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(addonDir, SuppressionsFileName), []byte(content), 0o644))
+
+	sups, err = LoadSuppressions(addonDir)
+	require.NoError(t, err)
+	require.Len(t, sups, 1)
+
+	assert.Equal(t, validator.Code(12), sups[0].Code)
+	assert.Equal(t, "Manages arbitrary CRDs.", sups[0].Justification)
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AddonImageSetReconciler validates AddonImageSet objects combined
+// with the AddonMetadata of their addon and reports the result
+// through the conditions of the AddonImageSet status.
+type AddonImageSetReconciler struct {
+	Client    client.Client
+	Log       logr.Logger
+	Validator MetaValidator
+}
+
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonimagesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonimagesets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonmetadata,verbs=get;list;watch
+
+func (r *AddonImageSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("addonimageset", req.NamespacedName)
+
+	var is v1alpha1.AddonImageSet
+
+	if err := r.Client.Get(ctx, req.NamespacedName, &is); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log.V(1).Info("validating")
+
+	validateErr := r.reconcileStatus(ctx, &is)
+
+	is.Status.ObservedGeneration = is.Generation
+
+	if err := r.Client.Status().Update(ctx, &is); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return ctrl.Result{}, validateErr
+}
+
+func (r *AddonImageSetReconciler) reconcileStatus(ctx context.Context, is *v1alpha1.AddonImageSet) error {
+	status := &is.Status.ValidationStatus
+	conds := &status.Conditions
+	generation := is.Generation
+	addonID := is.Spec.GetAddonID()
+
+	resetValidation(status)
+
+	addons, err := FindAddonMetadataForImageSet(ctx, r.Client, is)
+	if err != nil {
+		return err
+	}
+
+	if len(addons) == 0 {
+		apimeta.SetStatusCondition(conds, metav1.Condition{
+			Type:               v1alpha1.ConditionValidated,
+			Status:             metav1.ConditionUnknown,
+			ObservedGeneration: generation,
+			Reason:             v1alpha1.ReasonAddonMetadataNotFound,
+			Message:            fmt.Sprintf("AddonMetadata for addon %q not found.", addonID),
+		})
+
+		return nil
+	}
+
+	// the imageset is validated against the first AddonMetadata
+	// referencing it as they only differ in rare cases
+	addon := addons[0]
+
+	combined, err := addon.Spec.CombineWithImageSet(&is.Spec)
+	if err != nil {
+		apimeta.SetStatusCondition(conds, metav1.Condition{
+			Type:               v1alpha1.ConditionValidated,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             v1alpha1.ReasonInvalidMetadata,
+			Message:            err.Error(),
+		})
+
+		return nil
+	}
+
+	return validate(ctx, r.Validator, combined, &is.Spec, status, generation)
+}
+
+// SetupWithManager registers the reconciler with the given manager.
+func (r *AddonImageSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.AddonImageSet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAddonImageSetReconcilerReconcile(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ImageSet       *v1alpha1.AddonImageSet
+		Addons         []*v1alpha1.AddonMetadata
+		Results        validator.ResultList
+		ExpectedStatus metav1.ConditionStatus
+		ExpectedReason string
+		ExpectedCalls  int
+	}{
+		"metadata not found": {
+			ImageSet: newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+			Addons: []*v1alpha1.AddonMetadata{
+				newAddonMetadata("other-addon", "other-addon", nil, stringPtr("latest")),
+			},
+			ExpectedStatus: metav1.ConditionUnknown,
+			ExpectedReason: v1alpha1.ReasonAddonMetadataNotFound,
+		},
+		"invalid imageset name": {
+			ImageSet: newAddonImageSet("reference-addon", "reference-addon"),
+			Addons: []*v1alpha1.AddonMetadata{
+				newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("latest")),
+			},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: v1alpha1.ReasonInvalidMetadata,
+		},
+		"success": {
+			ImageSet: newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+			Addons: []*v1alpha1.AddonMetadata{
+				newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("latest")),
+			},
+			Results:        successResults(t),
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: v1alpha1.ReasonValidationSucceeded,
+			ExpectedCalls:  1,
+		},
+		"failure": {
+			ImageSet: newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+			Addons: []*v1alpha1.AddonMetadata{
+				newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("latest")),
+			},
+			Results:        failureResults(t, validator.SeverityError),
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: v1alpha1.ReasonValidationFailed,
+			ExpectedCalls:  1,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			objs := []client.Object{tc.ImageSet}
+			for _, addon := range tc.Addons {
+				objs = append(objs, addon)
+			}
+
+			c := newFakeClient(t, objs...)
+			v := &stubValidator{results: tc.Results}
+
+			r := &AddonImageSetReconciler{
+				Client:    c,
+				Log:       logr.Discard(),
+				Validator: v,
+			}
+
+			key := client.ObjectKeyFromObject(tc.ImageSet)
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			require.NoError(t, err)
+
+			var actual v1alpha1.AddonImageSet
+			require.NoError(t, c.Get(context.Background(), key, &actual))
+
+			assert.Equal(t, actual.Generation, actual.Status.ObservedGeneration)
+
+			valid := apimeta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionValidated)
+			require.NotNil(t, valid)
+
+			assert.Equal(t, tc.ExpectedStatus, valid.Status)
+			assert.Equal(t, tc.ExpectedReason, valid.Reason)
+
+			require.Len(t, v.called, tc.ExpectedCalls)
+
+			if tc.ExpectedCalls > 0 {
+				assert.Equal(t, tc.ImageSet.Spec.IndexImage, *v.called[0].IndexImage)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// AddonMetadataReconciler validates AddonMetadata objects combined
+// with their referenced AddonImageSet and reports the result through
+// the conditions of the AddonMetadata status.
+type AddonMetadataReconciler struct {
+	Client    client.Client
+	Log       logr.Logger
+	Validator MetaValidator
+}
+
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonmetadata,verbs=get;list;watch
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonmetadata/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=addonsflow.redhat.openshift.io,resources=addonimagesets,verbs=get;list;watch
+
+func (r *AddonMetadataReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("addonmetadata", req.NamespacedName)
+
+	var addon v1alpha1.AddonMetadata
+
+	if err := r.Client.Get(ctx, req.NamespacedName, &addon); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log.V(1).Info("validating")
+
+	validateErr := r.reconcileStatus(ctx, &addon)
+
+	addon.Status.ObservedGeneration = addon.Generation
+
+	if err := r.Client.Status().Update(ctx, &addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return ctrl.Result{}, validateErr
+}
+
+func (r *AddonMetadataReconciler) reconcileStatus(ctx context.Context, addon *v1alpha1.AddonMetadata) error {
+	status := &addon.Status.ValidationStatus
+	conds := &status.Conditions
+	generation := addon.Generation
+	spec := &addon.Spec
+
+	resetValidation(status)
+
+	if err := spec.VerifyIndexImageSource(); err != nil {
+		setInvalidMetadata(conds, err, generation)
+
+		return nil
+	}
+
+	if spec.IndexImage != nil {
+		apimeta.SetStatusCondition(conds, metav1.Condition{
+			Type:               v1alpha1.ConditionImageSetResolved,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             v1alpha1.ReasonStaticIndexImage,
+			Message:            "The addon uses a static indexImage.",
+		})
+
+		return validate(ctx, r.Validator, spec, nil, status, generation)
+	}
+
+	is, found, err := FindImageSet(ctx, r.Client, addon.Namespace, spec.ID, *spec.ImageSetVersion)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		msg := fmt.Sprintf("AddonImageSet for addon %q with version %q not found.", spec.ID, *spec.ImageSetVersion)
+
+		apimeta.SetStatusCondition(conds, metav1.Condition{
+			Type:               v1alpha1.ConditionImageSetResolved,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             v1alpha1.ReasonImageSetNotFound,
+			Message:            msg,
+		})
+		apimeta.SetStatusCondition(conds, metav1.Condition{
+			Type:               v1alpha1.ConditionValidated,
+			Status:             metav1.ConditionUnknown,
+			ObservedGeneration: generation,
+			Reason:             v1alpha1.ReasonImageSetNotFound,
+			Message:            msg,
+		})
+
+		// the AddonImageSet watch requeues the
+		// object once the imageset is created
+		return nil
+	}
+
+	apimeta.SetStatusCondition(conds, metav1.Condition{
+		Type:               v1alpha1.ConditionImageSetResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonImageSetFound,
+		Message:            fmt.Sprintf("Using AddonImageSet %q.", is.Name),
+	})
+
+	combined, err := spec.CombineWithImageSet(&is.Spec)
+	if err != nil {
+		setInvalidMetadata(conds, err, generation)
+
+		return nil
+	}
+
+	return validate(ctx, r.Validator, combined, &is.Spec, status, generation)
+}
+
+func setInvalidMetadata(conds *[]metav1.Condition, err error, generation int64) {
+	apimeta.SetStatusCondition(conds, metav1.Condition{
+		Type:               v1alpha1.ConditionValidated,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonInvalidMetadata,
+		Message:            err.Error(),
+	})
+}
+
+// SetupWithManager registers the reconciler with the given manager.
+// AddonMetadata objects are requeued whenever an AddonImageSet of
+// the same addon changes.
+func (r *AddonMetadataReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.AddonMetadata{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&v1alpha1.AddonImageSet{},
+			handler.EnqueueRequestsFromMapFunc(r.addonMetadataForImageSet),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *AddonMetadataReconciler) addonMetadataForImageSet(ctx context.Context, obj client.Object) []reconcile.Request {
+	is, ok := obj.(*v1alpha1.AddonImageSet)
+	if !ok {
+		return nil
+	}
+
+	addons, err := FindAddonMetadata(ctx, r.Client, is.Namespace, is.Spec.GetAddonID())
+	if err != nil {
+		r.Log.Error(err, "mapping AddonImageSet to AddonMetadata", "addonimageset", client.ObjectKeyFromObject(is))
+
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(addons))
+
+	for _, addon := range addons {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: addon.Namespace, Name: addon.Name},
+		})
+	}
+
+	return reqs
+}
+
+// FindAddonMetadata returns all AddonMetadata objects in the given
+// namespace with the given addon id sorted by name.
+func FindAddonMetadata(ctx context.Context, c client.Reader, namespace, id string) ([]v1alpha1.AddonMetadata, error) {
+	var list v1alpha1.AddonMetadataList
+
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing AddonMetadata: %w", err)
+	}
+
+	var res []v1alpha1.AddonMetadata
+
+	for _, addon := range list.Items {
+		if addon.Spec.ID == id {
+			res = append(res, addon)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
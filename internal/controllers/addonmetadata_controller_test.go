@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAddonMetadataReconcilerReconcile(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Addon              *v1alpha1.AddonMetadata
+		ImageSets          []*v1alpha1.AddonImageSet
+		Results            validator.ResultList
+		ValidateErr        error
+		ExpectedError      bool
+		ExpectedConditions map[string]metav1.ConditionStatus
+		ExpectedReason     string
+		ExpectedIndexImage string
+	}{
+		"static indexImage/success": {
+			Addon:   newAddonMetadata("reference-addon", "reference-addon", stringPtr("quay.io/osd-addons/static"), nil),
+			Results: successResults(t),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionTrue,
+				v1alpha1.ConditionValidated:        metav1.ConditionTrue,
+			},
+			ExpectedReason:     v1alpha1.ReasonValidationSucceeded,
+			ExpectedIndexImage: "quay.io/osd-addons/static",
+		},
+		"conflicting indexImage": {
+			Addon: newAddonMetadata("reference-addon", "reference-addon",
+				stringPtr("quay.io/osd-addons/static"), stringPtr("0.1.0")),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionValidated: metav1.ConditionFalse,
+			},
+			ExpectedReason: v1alpha1.ReasonInvalidMetadata,
+		},
+		"missing indexImage": {
+			Addon: newAddonMetadata("reference-addon", "reference-addon", nil, nil),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionValidated: metav1.ConditionFalse,
+			},
+			ExpectedReason: v1alpha1.ReasonInvalidMetadata,
+		},
+		"imageset not found": {
+			Addon: newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("0.2.0")),
+			ImageSets: []*v1alpha1.AddonImageSet{
+				newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+				newAddonImageSet("other-addon-v0.2.0", "other-addon.v0.2.0"),
+			},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionFalse,
+				v1alpha1.ConditionValidated:        metav1.ConditionUnknown,
+			},
+			ExpectedReason: v1alpha1.ReasonImageSetNotFound,
+		},
+		"imageset version/failure": {
+			Addon: newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("0.1.0")),
+			ImageSets: []*v1alpha1.AddonImageSet{
+				newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+				newAddonImageSet("reference-addon-v0.2.0", "reference-addon.v0.2.0"),
+			},
+			Results: failureResults(t, validator.SeverityError),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionTrue,
+				v1alpha1.ConditionValidated:        metav1.ConditionFalse,
+			},
+			ExpectedReason:     v1alpha1.ReasonValidationFailed,
+			ExpectedIndexImage: "quay.io/osd-addons/reference-addon-v0.1.0",
+		},
+		"latest imageset/warning": {
+			Addon: newAddonMetadata("reference-addon", "reference-addon", nil, stringPtr("latest")),
+			ImageSets: []*v1alpha1.AddonImageSet{
+				newAddonImageSet("reference-addon-v0.10.0", "reference-addon.v0.10.0"),
+				newAddonImageSet("reference-addon-v0.9.0", "reference-addon.v0.9.0"),
+			},
+			Results: failureResults(t, validator.SeverityWarning),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionTrue,
+				v1alpha1.ConditionValidated:        metav1.ConditionTrue,
+			},
+			ExpectedReason:     v1alpha1.ReasonValidationSucceeded,
+			ExpectedIndexImage: "quay.io/osd-addons/reference-addon-v0.10.0",
+		},
+		"validator errors": {
+			Addon:   newAddonMetadata("reference-addon", "reference-addon", stringPtr("quay.io/osd-addons/static"), nil),
+			Results: errorResults(t),
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionTrue,
+				v1alpha1.ConditionValidated:        metav1.ConditionUnknown,
+			},
+			ExpectedReason:     v1alpha1.ReasonValidationErrored,
+			ExpectedIndexImage: "quay.io/osd-addons/static",
+		},
+		"bundle extraction fails": {
+			Addon:         newAddonMetadata("reference-addon", "reference-addon", stringPtr("quay.io/osd-addons/static"), nil),
+			ValidateErr:   fmt.Errorf("%w: %w", ErrExtractingBundles, errors.New("pull failed")),
+			ExpectedError: true,
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				v1alpha1.ConditionImageSetResolved: metav1.ConditionTrue,
+				v1alpha1.ConditionValidated:        metav1.ConditionUnknown,
+			},
+			ExpectedReason:     v1alpha1.ReasonBundleExtractionFailed,
+			ExpectedIndexImage: "quay.io/osd-addons/static",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			objs := []client.Object{tc.Addon}
+			for _, is := range tc.ImageSets {
+				objs = append(objs, is)
+			}
+
+			c := newFakeClient(t, objs...)
+			v := &stubValidator{results: tc.Results, err: tc.ValidateErr}
+
+			r := &AddonMetadataReconciler{
+				Client:    c,
+				Log:       logr.Discard(),
+				Validator: v,
+			}
+
+			key := client.ObjectKeyFromObject(tc.Addon)
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if tc.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var actual v1alpha1.AddonMetadata
+			require.NoError(t, c.Get(context.Background(), key, &actual))
+
+			assert.Equal(t, actual.Generation, actual.Status.ObservedGeneration)
+			assert.Len(t, actual.Status.Conditions, len(tc.ExpectedConditions))
+
+			for condType, status := range tc.ExpectedConditions {
+				cond := apimeta.FindStatusCondition(actual.Status.Conditions, condType)
+				require.NotNil(t, cond, condType)
+
+				assert.Equal(t, status, cond.Status, condType)
+			}
+
+			valid := apimeta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionValidated)
+			assert.Equal(t, tc.ExpectedReason, valid.Reason)
+
+			if tc.ExpectedIndexImage == "" {
+				assert.Empty(t, v.called)
+
+				return
+			}
+
+			require.Len(t, v.called, 1)
+			assert.Equal(t, tc.ExpectedIndexImage, *v.called[0].IndexImage)
+
+			if tc.ValidateErr != nil {
+				assert.Empty(t, actual.Status.Results)
+
+				return
+			}
+
+			assert.Equal(t, tc.ExpectedIndexImage, actual.Status.IndexImage)
+			assert.Len(t, actual.Status.Bundles, 1)
+			assert.Len(t, actual.Status.Results, len(tc.Results))
+		})
+	}
+}
+
+func TestAddonMetadataForImageSet(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClient(t,
+		newAddonMetadata("b", "reference-addon", nil, stringPtr("latest")),
+		newAddonMetadata("a", "reference-addon", nil, stringPtr("latest")),
+		newAddonMetadata("c", "other-addon", nil, stringPtr("latest")),
+	)
+
+	r := &AddonMetadataReconciler{
+		Client: c,
+		Log:    logr.Discard(),
+	}
+
+	reqs := r.addonMetadataForImageSet(context.Background(),
+		newAddonImageSet("reference-addon-v0.1.0", "reference-addon.v0.1.0"),
+	)
+
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "a", reqs[0].Name)
+	assert.Equal(t, "b", reqs[1].Name)
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "addons"
+
+// stubValidator records the metadata it is called with
+// and returns fixed results.
+type stubValidator struct {
+	results validator.ResultList
+	err     error
+	called  []*v1alpha1.AddonMetadataSpec
+}
+
+func (v *stubValidator) Validate(_ context.Context, meta *v1alpha1.AddonMetadataSpec, _ *v1alpha1.AddonImageSetSpec) (types.MetaBundle, validator.ResultList, error) {
+	v.called = append(v.called, meta)
+
+	if v.err != nil {
+		return types.MetaBundle{}, nil, v.err
+	}
+
+	mb := types.MetaBundle{
+		AddonMeta: meta,
+		Bundles: []operator.Bundle{
+			{Name: meta.OperatorName + ".v0.1.0", Version: "0.1.0"},
+		},
+	}
+
+	return mb, v.results, nil
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.AddonMetadata{}, &v1alpha1.AddonImageSet{}).
+		Build()
+}
+
+func newAddonMetadata(name, id string, indexImage, imageSetVersion *string) *v1alpha1.AddonMetadata {
+	return &v1alpha1.AddonMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Generation: 1},
+		Spec: v1alpha1.AddonMetadataSpec{
+			ID:              id,
+			OperatorName:    id,
+			IndexImage:      indexImage,
+			ImageSetVersion: imageSetVersion,
+		},
+	}
+}
+
+func newAddonImageSet(name, specName string) *v1alpha1.AddonImageSet {
+	return &v1alpha1.AddonImageSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, Generation: 1},
+		Spec: v1alpha1.AddonImageSetSpec{
+			Name:          specName,
+			IndexImage:    "quay.io/osd-addons/" + name,
+			RelatedImages: []string{},
+		},
+	}
+}
+
+func stringPtr(s string) *string { return &s }
+
+func newTestBase(t *testing.T, sev validator.Severity) *validator.Base {
+	t.Helper()
+
+	base, err := validator.NewBase(1,
+		validator.BaseName("test"),
+		validator.BaseSeverity(sev),
+	)
+	require.NoError(t, err)
+
+	return base
+}
+
+func successResults(t *testing.T) validator.ResultList {
+	t.Helper()
+
+	return validator.ResultList{newTestBase(t, validator.SeverityError).Success()}
+}
+
+func failureResults(t *testing.T, sev validator.Severity) validator.ResultList {
+	t.Helper()
+
+	return validator.ResultList{newTestBase(t, sev).Fail("something is wrong")}
+}
+
+func errorResults(t *testing.T) validator.ResultList {
+	t.Helper()
+
+	return validator.ResultList{newTestBase(t, validator.SeverityError).Error(errors.New("service unavailable"))}
+}
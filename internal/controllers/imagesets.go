@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"golang.org/x/mod/semver"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const latestVersion = "latest"
+
+// FindImageSet returns the AddonImageSet in the given namespace which
+// belongs to the addon with the given id and matches the given
+// "MAJOR.MINOR.PATCH" version. The imageset with the highest version
+// is returned for the "latest" version. 'false' is returned if no
+// such imageset exists.
+func FindImageSet(ctx context.Context, c client.Reader, namespace, addonID, version string) (*v1alpha1.AddonImageSet, bool, error) {
+	var list v1alpha1.AddonImageSetList
+
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, false, fmt.Errorf("listing AddonImageSets: %w", err)
+	}
+
+	var candidates []v1alpha1.AddonImageSet
+
+	for _, is := range list.Items {
+		if is.Spec.GetAddonID() != addonID {
+			continue
+		}
+
+		isVersion, err := is.Spec.GetSemver()
+		if err != nil {
+			continue
+		}
+
+		if version == latestVersion || isVersion == version {
+			candidates = append(candidates, is)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, false, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		iVer, _ := candidates[i].Spec.GetSemver()
+		jVer, _ := candidates[j].Spec.GetSemver()
+
+		return semver.Compare("v"+iVer, "v"+jVer) > 0
+	})
+
+	return &candidates[0], true, nil
+}
+
+// FindAddonMetadataForImageSet returns the AddonMetadata objects of the
+// imageset's addon which reference the given imageset either by its
+// version or as the "latest" imageset of the addon, sorted by name.
+// If none reference the imageset the first AddonMetadata of the addon
+// is returned so that unreferenced imagesets are still validated.
+func FindAddonMetadataForImageSet(ctx context.Context, c client.Reader, is *v1alpha1.AddonImageSet) ([]v1alpha1.AddonMetadata, error) {
+	addons, err := FindAddonMetadata(ctx, c, is.Namespace, is.Spec.GetAddonID())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addons) == 0 {
+		return nil, nil
+	}
+
+	version, err := is.Spec.GetSemver()
+	if err != nil {
+		// imagesets without a valid version can't be referenced
+		return addons[:1], nil
+	}
+
+	isLatest, err := isLatestImageSet(ctx, c, is, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []v1alpha1.AddonMetadata
+
+	for _, addon := range addons {
+		ref := addon.Spec.ImageSetVersion
+		if ref == nil {
+			continue
+		}
+
+		if *ref == version || (*ref == latestVersion && isLatest) {
+			res = append(res, addon)
+		}
+	}
+
+	if len(res) == 0 {
+		return addons[:1], nil
+	}
+
+	return res, nil
+}
+
+// isLatestImageSet returns 'true' if no other imageset of the given
+// imageset's addon has a higher version than the given one.
+func isLatestImageSet(ctx context.Context, c client.Reader, is *v1alpha1.AddonImageSet, version string) (bool, error) {
+	var list v1alpha1.AddonImageSetList
+
+	if err := c.List(ctx, &list, client.InNamespace(is.Namespace)); err != nil {
+		return false, fmt.Errorf("listing AddonImageSets: %w", err)
+	}
+
+	for _, other := range list.Items {
+		if other.Name == is.Name || other.Spec.GetAddonID() != is.Spec.GetAddonID() {
+			continue
+		}
+
+		otherVersion, err := other.Spec.GetSemver()
+		if err != nil {
+			continue
+		}
+
+		if semver.Compare("v"+otherVersion, "v"+version) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
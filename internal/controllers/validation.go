@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MetaValidator validates addon metadata which has already been
+// combined with the given imageset. The imageset is nil for metadata
+// using a static indexImage. The validated MetaBundle is returned
+// along with the results.
+type MetaValidator interface {
+	Validate(ctx context.Context, meta *v1alpha1.AddonMetadataSpec, imageSet *v1alpha1.AddonImageSetSpec) (types.MetaBundle, validator.ResultList, error)
+}
+
+// NewRunnerValidator returns a MetaValidator which extracts the
+// bundles of the metadata's indexImage using the given extractor
+// and runs the validators of the given runner against them.
+func NewRunnerValidator(ex extractor.Extractor, runner *validator.Runner) *RunnerValidator {
+	return &RunnerValidator{
+		extractor: ex,
+		runner:    runner,
+	}
+}
+
+type RunnerValidator struct {
+	extractor extractor.Extractor
+	runner    *validator.Runner
+}
+
+var (
+	ErrMissingIndexImage = errors.New("metadata has no indexImage")
+	ErrExtractingBundles = errors.New("extracting bundles")
+)
+
+func (v *RunnerValidator) Validate(ctx context.Context, meta *v1alpha1.AddonMetadataSpec, imageSet *v1alpha1.AddonImageSetSpec) (types.MetaBundle, validator.ResultList, error) {
+	if meta.IndexImage == nil {
+		return types.MetaBundle{}, nil, ErrMissingIndexImage
+	}
+
+	bundles, err := v.extractor.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+	if err != nil {
+		return types.MetaBundle{}, nil, fmt.Errorf("%w: %w", ErrExtractingBundles, err)
+	}
+
+	mb := types.MetaBundle{
+		AddonMeta: meta,
+		ImageSet:  imageSet,
+		Bundles:   bundles,
+	}
+
+	var results validator.ResultList
+
+	for res := range v.runner.Run(ctx, mb) {
+		results = append(results, res)
+	}
+
+	sort.Stable(results)
+
+	return mb, results, nil
+}
+
+// validationErrorCondition returns a 'Validated' condition for metadata
+// which could not be validated due to the given error.
+func validationErrorCondition(err error, generation int64) metav1.Condition {
+	reason := v1alpha1.ReasonValidationErrored
+	if errors.Is(err, ErrExtractingBundles) {
+		reason = v1alpha1.ReasonBundleExtractionFailed
+	}
+
+	return metav1.Condition{
+		Type:               v1alpha1.ConditionValidated,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	}
+}
+
+// validate runs the given MetaValidator and records the results in
+// the given status. The error of the MetaValidator is returned so
+// that the object is requeued.
+func validate(ctx context.Context, v MetaValidator, meta *v1alpha1.AddonMetadataSpec, imageSet *v1alpha1.AddonImageSetSpec, status *v1alpha1.ValidationStatus, generation int64) error {
+	mb, results, err := v.Validate(ctx, meta, imageSet)
+	if err != nil {
+		apimeta.SetStatusCondition(&status.Conditions, validationErrorCondition(err, generation))
+
+		return fmt.Errorf("validating metadata: %w", err)
+	}
+
+	validator.SetValidationStatus(status, mb, results, generation)
+
+	return nil
+}
+
+// resetValidation clears the results of a previous validation
+// from the given status.
+func resetValidation(status *v1alpha1.ValidationStatus) {
+	status.IndexImage = ""
+	status.Bundles = nil
+	status.Results = nil
+}
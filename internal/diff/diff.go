@@ -0,0 +1,346 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	rbac "k8s.io/api/rbac/v1"
+)
+
+// Source holds one side of a comparison.
+type Source struct {
+	Meta *addonsv1alpha1.AddonMetadataSpec
+	// ImageSet is nil for addons using a static indexImage.
+	ImageSet *addonsv1alpha1.AddonImageSetSpec
+	Bundles  []operator.Bundle
+}
+
+// Load loads the addon metadata of the given addon directory,
+// environment and imageset version through a MetaLoader and extracts
+// its bundles using the given extractor.
+func Load(ctx context.Context, ex extractor.Extractor, addonDir, env, version string) (Source, error) {
+	meta, err := utils.NewMetaLoader(addonDir, env, version).Load()
+	if err != nil {
+		return Source{}, fmt.Errorf("loading addon metadata: %w", err)
+	}
+
+	var imageSet *addonsv1alpha1.AddonImageSetSpec
+
+	if meta.ImageSetVersion != nil {
+		imageSet, err = utils.LoadImageSet(addonDir, env, *meta.ImageSetVersion)
+		if err != nil {
+			return Source{}, fmt.Errorf("loading imageset: %w", err)
+		}
+	}
+
+	bundles, err := ex.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+	if err != nil {
+		return Source{}, fmt.Errorf("extracting and parsing addon bundles: %w", err)
+	}
+
+	return Source{
+		Meta:     meta,
+		ImageSet: imageSet,
+		Bundles:  bundles,
+	}, nil
+}
+
+// Kind identifies the part of an addon a Change applies to.
+type Kind string
+
+const (
+	KindIndexImage        Kind = "index image"
+	KindBundle            Kind = "bundle"
+	KindCSVVersion        Kind = "csv version"
+	KindPermission        Kind = "permission"
+	KindClusterPermission Kind = "cluster permission"
+	KindOwnedCRD          Kind = "owned crd"
+	KindRequiredCRD       Kind = "required crd"
+	KindAddonParameter    Kind = "addon parameter"
+	KindAddonRequirement  Kind = "addon requirement"
+	KindRelatedImage      Kind = "related image"
+)
+
+type Action string
+
+const (
+	ActionAdded   Action = "added"
+	ActionRemoved Action = "removed"
+	ActionChanged Action = "changed"
+)
+
+// Change describes a single semantic difference between two sources.
+// From and To hold the previous and new value of changed items.
+type Change struct {
+	Kind   Kind   `json:"kind"`
+	Action Action `json:"action"`
+	Name   string `json:"name"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// Compare returns the changes required to get from one source to the
+// other. Changes are grouped by kind and sorted by name within each
+// kind. CSV versions, permissions and CRDs are compared between the
+// latest bundles of each package.
+func Compare(from, to Source) ([]Change, error) {
+	var changes []Change
+
+	changes = append(changes, compareIndexImages(from, to)...)
+	changes = append(changes, compareSets(KindBundle, bundleNames(from.Bundles), bundleNames(to.Bundles))...)
+
+	fromHeads, toHeads := headBundles(from.Bundles), headBundles(to.Bundles)
+
+	changes = append(changes, compareSets(KindCSVVersion, csvVersions(fromHeads), csvVersions(toHeads))...)
+	changes = append(changes, compareSets(KindPermission, permissions(fromHeads, false), permissions(toHeads, false))...)
+	changes = append(changes, compareSets(KindClusterPermission, permissions(fromHeads, true), permissions(toHeads, true))...)
+	changes = append(changes, compareSets(KindOwnedCRD, ownedCRDs(fromHeads), ownedCRDs(toHeads))...)
+	changes = append(changes, compareSets(KindRequiredCRD, requiredCRDs(fromHeads), requiredCRDs(toHeads))...)
+
+	fromParams, err := addonParameters(from.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	toParams, err := addonParameters(to.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	changes = append(changes, compareSets(KindAddonParameter, fromParams, toParams)...)
+
+	fromReqs, err := addonRequirements(from.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	toReqs, err := addonRequirements(to.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	changes = append(changes, compareSets(KindAddonRequirement, fromReqs, toReqs)...)
+	changes = append(changes, compareSets(KindRelatedImage, relatedImages(from.ImageSet), relatedImages(to.ImageSet))...)
+
+	return changes, nil
+}
+
+func compareIndexImages(from, to Source) []Change {
+	fromImage, toImage := indexImage(from.Meta), indexImage(to.Meta)
+	if fromImage == toImage {
+		return nil
+	}
+
+	return []Change{{
+		Kind:   KindIndexImage,
+		Action: ActionChanged,
+		Name:   "indexImage",
+		From:   fromImage,
+		To:     toImage,
+	}}
+}
+
+func indexImage(meta *addonsv1alpha1.AddonMetadataSpec) string {
+	if meta == nil || meta.IndexImage == nil {
+		return ""
+	}
+
+	return *meta.IndexImage
+}
+
+// compareSets compares two sets of named items. Items only present in
+// 'to' are added, items only present in 'from' are removed and items
+// present in both with different values are changed.
+func compareSets(kind Kind, from, to map[string]string) []Change {
+	var changes []Change
+
+	for name, fromVal := range from {
+		toVal, ok := to[name]
+		if !ok {
+			changes = append(changes, Change{Kind: kind, Action: ActionRemoved, Name: name})
+		} else if fromVal != toVal {
+			changes = append(changes, Change{Kind: kind, Action: ActionChanged, Name: name, From: fromVal, To: toVal})
+		}
+	}
+
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			changes = append(changes, Change{Kind: kind, Action: ActionAdded, Name: name})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+func bundleNames(bundles []operator.Bundle) map[string]string {
+	res := make(map[string]string, len(bundles))
+
+	for _, b := range bundles {
+		res[b.ClusterServiceVersion.Name] = ""
+	}
+
+	return res
+}
+
+// headBundles returns the latest bundle of every package.
+func headBundles(bundles []operator.Bundle) map[string]operator.Bundle {
+	byPackage := make(map[string][]operator.Bundle)
+
+	for _, b := range bundles {
+		pkg := b.Annotations.PackageName
+		byPackage[pkg] = append(byPackage[pkg], b)
+	}
+
+	res := make(map[string]operator.Bundle, len(byPackage))
+
+	for pkg, bs := range byPackage {
+		if head, ok := operator.HeadBundle(bs...); ok {
+			res[pkg] = head
+		}
+	}
+
+	return res
+}
+
+func csvVersions(heads map[string]operator.Bundle) map[string]string {
+	res := make(map[string]string, len(heads))
+
+	for pkg, b := range heads {
+		res[pkg] = b.Version
+	}
+
+	return res
+}
+
+// permissions returns one item per service account and policy rule
+// of the given bundles.
+func permissions(heads map[string]operator.Bundle, clusterScoped bool) map[string]string {
+	res := make(map[string]string)
+
+	for _, b := range heads {
+		strategy := b.ClusterServiceVersion.Spec.InstallStrategy.StrategySpec
+
+		perms := strategy.Permissions
+		if clusterScoped {
+			perms = strategy.ClusterPermissions
+		}
+
+		for _, p := range perms {
+			for _, rule := range p.Rules {
+				res[fmt.Sprintf("%s: %s", p.ServiceAccountName, ruleString(rule))] = ""
+			}
+		}
+	}
+
+	return res
+}
+
+func ruleString(rule rbac.PolicyRule) string {
+	parts := []string{
+		fmt.Sprintf("apiGroups=[%s]", strings.Join(rule.APIGroups, ",")),
+		fmt.Sprintf("resources=[%s]", strings.Join(rule.Resources, ",")),
+	}
+
+	if len(rule.ResourceNames) > 0 {
+		parts = append(parts, fmt.Sprintf("resourceNames=[%s]", strings.Join(rule.ResourceNames, ",")))
+	}
+
+	if len(rule.NonResourceURLs) > 0 {
+		parts = append(parts, fmt.Sprintf("nonResourceURLs=[%s]", strings.Join(rule.NonResourceURLs, ",")))
+	}
+
+	parts = append(parts, fmt.Sprintf("verbs=[%s]", strings.Join(rule.Verbs, ",")))
+
+	return strings.Join(parts, " ")
+}
+
+func ownedCRDs(heads map[string]operator.Bundle) map[string]string {
+	res := make(map[string]string)
+
+	for _, b := range heads {
+		for _, crd := range b.ClusterServiceVersion.OwnedCustomResourceDefinitions {
+			res[crdName(crd)] = ""
+		}
+	}
+
+	return res
+}
+
+func requiredCRDs(heads map[string]operator.Bundle) map[string]string {
+	res := make(map[string]string)
+
+	for _, b := range heads {
+		for _, crd := range b.ClusterServiceVersion.RequiredCustomResourceDefinitions {
+			res[crdName(crd)] = ""
+		}
+	}
+
+	return res
+}
+
+func crdName(crd operator.CustomResourceDefinition) string {
+	return fmt.Sprintf("%s/%s", crd.Name, crd.Version)
+}
+
+func addonParameters(meta *addonsv1alpha1.AddonMetadataSpec) (map[string]string, error) {
+	res := make(map[string]string)
+
+	if meta == nil || meta.AddOnParameters == nil {
+		return res, nil
+	}
+
+	for _, p := range *meta.AddOnParameters {
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return nil, fmt.Errorf("encoding addon parameter %q: %w", p.ID, err)
+		}
+
+		res[p.ID] = string(raw)
+	}
+
+	return res, nil
+}
+
+func addonRequirements(meta *addonsv1alpha1.AddonMetadataSpec) (map[string]string, error) {
+	res := make(map[string]string)
+
+	if meta == nil || meta.AddOnRequirements == nil {
+		return res, nil
+	}
+
+	for _, r := range *meta.AddOnRequirements {
+		raw, err := json.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("encoding addon requirement %q: %w", r.ID, err)
+		}
+
+		res[r.ID] = string(raw)
+	}
+
+	return res, nil
+}
+
+func relatedImages(imageSet *addonsv1alpha1.AddonImageSetSpec) map[string]string {
+	res := make(map[string]string)
+
+	if imageSet == nil {
+		return res
+	}
+
+	for _, img := range imageSet.RelatedImages {
+		res[img] = ""
+	}
+
+	return res
+}
@@ -0,0 +1,198 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbac "k8s.io/api/rbac/v1"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		From     Source
+		To       Source
+		Expected []Change
+	}{
+		"identical": {
+			From: newSource("0.1.0"),
+			To:   newSource("0.1.0"),
+		},
+		"bundle and csv version": {
+			From: newSource("0.1.0"),
+			To:   newSource("0.1.0", "0.2.0"),
+			Expected: []Change{
+				{Kind: KindBundle, Action: ActionAdded, Name: "reference-addon.v0.2.0"},
+				{Kind: KindCSVVersion, Action: ActionChanged, Name: "reference-addon", From: "0.1.0", To: "0.2.0"},
+			},
+		},
+		"removed bundle": {
+			From: newSource("0.1.0", "0.2.0"),
+			To:   newSource("0.2.0"),
+			Expected: []Change{
+				{Kind: KindBundle, Action: ActionRemoved, Name: "reference-addon.v0.1.0"},
+			},
+		},
+		"permissions": {
+			From: newSource("0.1.0"),
+			To: withBundles(newSource("0.1.0"), func(csv *operator.ClusterServiceVersion) {
+				strategy := &csv.Spec.InstallStrategy.StrategySpec
+
+				strategy.Permissions = []opsv1alpha1.StrategyDeploymentPermissions{{
+					ServiceAccountName: "operator",
+					Rules: []rbac.PolicyRule{{
+						APIGroups: []string{""},
+						Resources: []string{"secrets"},
+						Verbs:     []string{"get"},
+					}},
+				}}
+				strategy.ClusterPermissions = []opsv1alpha1.StrategyDeploymentPermissions{{
+					ServiceAccountName: "operator",
+					Rules: []rbac.PolicyRule{{
+						NonResourceURLs: []string{"/metrics"},
+						Verbs:           []string{"get"},
+					}},
+				}}
+			}),
+			Expected: []Change{
+				{Kind: KindPermission, Action: ActionAdded, Name: "operator: apiGroups=[] resources=[secrets] verbs=[get]"},
+				{Kind: KindClusterPermission, Action: ActionAdded, Name: "operator: apiGroups=[] resources=[] nonResourceURLs=[/metrics] verbs=[get]"},
+			},
+		},
+		"crds": {
+			From: withBundles(newSource("0.1.0"), func(csv *operator.ClusterServiceVersion) {
+				csv.RequiredCustomResourceDefinitions = []operator.CustomResourceDefinition{
+					{Name: "foos.example.com", Version: "v1"},
+				}
+			}),
+			To: withBundles(newSource("0.1.0"), func(csv *operator.ClusterServiceVersion) {
+				csv.OwnedCustomResourceDefinitions = []operator.CustomResourceDefinition{
+					{Name: "bars.example.com", Version: "v1alpha1"},
+				}
+			}),
+			Expected: []Change{
+				{Kind: KindOwnedCRD, Action: ActionAdded, Name: "bars.example.com/v1alpha1"},
+				{Kind: KindRequiredCRD, Action: ActionRemoved, Name: "foos.example.com/v1"},
+			},
+		},
+		"metadata and imageset": {
+			From: newSource("0.1.0"),
+			To: func() Source {
+				s := newSource("0.1.0")
+
+				indexImage := "quay.io/osd-addons/reference-addon-index:v2"
+				s.Meta.IndexImage = &indexImage
+				s.Meta.AddOnParameters = &[]ocmv1.AddOnParameter{{ID: "size", Name: "Size"}}
+				s.Meta.AddOnRequirements = &[]ocmv1.AddOnRequirement{{ID: "nodes"}}
+				s.ImageSet.RelatedImages = []string{"quay.io/osd-addons/reference-addon:v2"}
+
+				return s
+			}(),
+			Expected: []Change{
+				{
+					Kind:   KindIndexImage,
+					Action: ActionChanged,
+					Name:   "indexImage",
+					From:   "quay.io/osd-addons/reference-addon-index:v1",
+					To:     "quay.io/osd-addons/reference-addon-index:v2",
+				},
+				{Kind: KindAddonParameter, Action: ActionAdded, Name: "size"},
+				{Kind: KindAddonRequirement, Action: ActionAdded, Name: "nodes"},
+				{Kind: KindRelatedImage, Action: ActionAdded, Name: "quay.io/osd-addons/reference-addon:v2"},
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := Compare(tc.From, tc.To)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, changes)
+		})
+	}
+}
+
+func TestCompareChangedAddonParameter(t *testing.T) {
+	t.Parallel()
+
+	from, to := newSource("0.1.0"), newSource("0.1.0")
+	from.Meta.AddOnParameters = &[]ocmv1.AddOnParameter{{ID: "size", Name: "Size"}}
+	to.Meta.AddOnParameters = &[]ocmv1.AddOnParameter{{ID: "size", Name: "Cluster Size"}}
+
+	changes, err := Compare(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+
+	assert.Equal(t, KindAddonParameter, changes[0].Kind)
+	assert.Equal(t, ActionChanged, changes[0].Action)
+	assert.Equal(t, "size", changes[0].Name)
+	assert.Contains(t, changes[0].From, `"name":"Size"`)
+	assert.Contains(t, changes[0].To, `"name":"Cluster Size"`)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	src, err := Load(context.Background(), stubExtractor{}, refAddonStage.ImageSetDir(), "stage", "0.0.1")
+	require.NoError(t, err)
+
+	require.NotNil(t, src.ImageSet)
+	assert.Equal(t, "reference-addon.v0.0.1", src.ImageSet.Name)
+	assert.Equal(t, src.ImageSet.IndexImage, *src.Meta.IndexImage)
+}
+
+func newSource(versions ...string) Source {
+	indexImage := "quay.io/osd-addons/reference-addon-index:v1"
+
+	bundles := make([]operator.Bundle, 0, len(versions))
+
+	for _, v := range versions {
+		bundles = append(bundles, operator.Bundle{
+			Annotations: operator.Annotations{PackageName: "reference-addon"},
+			ClusterServiceVersion: operator.ClusterServiceVersion{
+				Name: "reference-addon.v" + v,
+			},
+			Version: v,
+		})
+	}
+
+	return Source{
+		Meta: &addonsv1alpha1.AddonMetadataSpec{
+			IndexImage: &indexImage,
+		},
+		ImageSet: &addonsv1alpha1.AddonImageSetSpec{},
+		Bundles:  bundles,
+	}
+}
+
+func withBundles(s Source, mutate func(*operator.ClusterServiceVersion)) Source {
+	for i := range s.Bundles {
+		mutate(&s.Bundles[i].ClusterServiceVersion)
+	}
+
+	return s
+}
+
+type stubExtractor struct{}
+
+func (e stubExtractor) ExtractBundles(context.Context, string, string) ([]operator.Bundle, error) {
+	return nil, nil
+}
+
+func (e stubExtractor) ExtractAllBundles(context.Context, string) ([]operator.Bundle, error) {
+	return nil, nil
+}
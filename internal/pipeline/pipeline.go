@@ -0,0 +1,399 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/config"
+	"github.com/mt-sre/addon-metadata-operator/internal/report"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const (
+	ocmTokenEnvVar        = "OCM_TOKEN"
+	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
+	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
+)
+
+// New returns a Pipeline configured with a variadic slice of options.
+// The Pipeline must be closed once it is no longer needed.
+func New(opts ...Option) *Pipeline {
+	var cfg Config
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Pipeline{
+		cfg: cfg,
+		ocm: make(map[string]*validator.OCMClientImpl),
+	}
+}
+
+// Pipeline validates addon directories by loading their metadata,
+// extracting the referenced bundles and running all registered
+// validators against the result. A Pipeline is safe for concurrent
+// use so that the extractor caches and OCM connections can be
+// shared when validating many addons.
+type Pipeline struct {
+	cfg Config
+
+	lock sync.Mutex
+	ocm  map[string]*validator.OCMClientImpl
+}
+
+// Validate validates each of the given targets of the addon in
+// addonDir and returns one report.Suite per target. Validation
+// config and suppressions are loaded from the addon directory.
+// Targets which can't be validated are reported through the Err
+// of their suite without affecting the remaining targets.
+func (p *Pipeline) Validate(ctx context.Context, addonDir string, targets ...Target) ([]report.Suite, error) {
+	settings, err := p.loadSettings(addonDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading validation config: %w", err)
+	}
+
+	suppressions, err := config.LoadSuppressions(addonDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading suppressions: %w", err)
+	}
+
+	filter := p.cfg.Filter
+
+	// options take precedence over the config file
+	if filter == nil && len(settings.Disabled) > 0 {
+		filter = validator.Not(validator.MatchesCodes(settings.Disabled...))
+	}
+
+	excludedNamespaces := p.cfg.ExcludedNamespaces
+	if len(excludedNamespaces) == 0 {
+		excludedNamespaces = settings.ExcludedNamespaces
+	}
+
+	// timeouts apply to each attempt of the configured middleware
+	timeout := validator.NewTimeoutMiddleware(
+		validator.WithDefaultTimeout(p.cfg.Timeout),
+		validator.WithTimeouts(settings.Timeouts),
+	)
+
+	runnerOpts := []validator.RunnerOption{
+		validator.WithAdditionalInitializers(p.cfg.Plugins),
+		validator.WithMiddleware(append([]validator.Middleware{timeout}, p.cfg.Middleware...)),
+		validator.WithSeverityOverrides(settings.Severities),
+		validator.WithValidatorOptions{
+			validator.WithExcludedNamespaces(excludedNamespaces),
+		},
+	}
+
+	if p.cfg.Offline {
+		runnerOpts = append(runnerOpts, validator.WithSkipped{
+			Filter: validator.RequiresAnyCapability(validator.NetworkCapabilities()...),
+			Reason: "requires network access",
+		})
+	} else if p.cfg.RegistryClient != nil {
+		runnerOpts = append(runnerOpts, validator.WithRegistryClient{RegistryClient: p.cfg.RegistryClient})
+	}
+
+	if p.cfg.SkipBundles {
+		runnerOpts = append(runnerOpts, validator.WithSkipped{
+			Filter: validator.RequiresAnyCapability(validator.CapabilityBundles),
+			Reason: "requires addon bundles",
+		})
+	}
+
+	suites := make([]report.Suite, 0, len(targets))
+
+	for _, t := range targets {
+		suite := report.Suite{
+			Name: targetName(addonDir, t),
+		}
+
+		if err := p.validateTarget(ctx, &suite, addonDir, t, filter, suppressions, runnerOpts...); err != nil {
+			suite.Err = err
+		}
+
+		suites = append(suites, suite)
+	}
+
+	return suites, nil
+}
+
+// validateTarget validates a single target of the addon in addonDir
+// and records the outcome in the given suite.
+func (p *Pipeline) validateTarget(
+	ctx context.Context,
+	suite *report.Suite,
+	addonDir string,
+	t Target,
+	filter validator.Filter,
+	suppressions []validator.Suppression,
+	runnerOpts ...validator.RunnerOption,
+) error {
+	meta, imageSet, err := utils.LoadWithImageSet(addonDir, t.Env, t.Version)
+	if err != nil {
+		return fmt.Errorf("loading addon metadata from '%s' for %s: %w", addonDir, t, err)
+	}
+
+	suite.Name = suiteName(addonDir, t.Env, meta)
+	suite.Sources = metaSources(addonDir, t.Env, meta)
+
+	var bundles []operator.Bundle
+
+	if !p.cfg.SkipBundles {
+		bundles, err = p.cfg.Extractor.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+		if err != nil {
+			return fmt.Errorf("extracting and parsing addon bundles for %s: %w", t, err)
+		}
+	}
+
+	if !p.cfg.Offline {
+		ocm, err := p.ocmClient(t.Env)
+		if err != nil {
+			return fmt.Errorf("initializing ocm client for %s: %w", t, err)
+		}
+
+		runnerOpts = append(slices.Clip(runnerOpts), validator.WithOCMClient{OCMClient: ocm})
+	}
+
+	runner, err := validator.NewRunner(runnerOpts...)
+	if err != nil {
+		return fmt.Errorf("initializing validators for %s: %w", t, err)
+	}
+
+	suite.MetaBundle = types.MetaBundle{
+		AddonMeta: meta,
+		ImageSet:  imageSet,
+		Bundles:   bundles,
+	}
+
+	var results validator.ResultList
+
+	for res := range runner.Run(ctx, suite.MetaBundle, filter) {
+		results = append(results, res)
+	}
+
+	sort.Stable(results)
+
+	suite.Results, suite.ExpiredSuppressions = results.Suppress(time.Now(), suppressions...)
+
+	return nil
+}
+
+// Close releases the connections held by the Pipeline.
+func (p *Pipeline) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for env, ocm := range p.ocm {
+		_ = ocm.CloseConnection()
+
+		delete(p.ocm, env)
+	}
+}
+
+// loadSettings loads the validation config from the configured path
+// or, if no path is configured, discovers it from the addon directory.
+// Empty settings are returned if no config file exists.
+func (p *Pipeline) loadSettings(addonDir string) (config.Settings, error) {
+	path := p.cfg.ConfigPath
+
+	if path == "" {
+		discovered, ok, err := config.Discover(addonDir)
+		if err != nil {
+			return config.Settings{}, fmt.Errorf("discovering config file: %w", err)
+		}
+
+		if !ok {
+			return config.Settings{}, nil
+		}
+
+		path = discovered
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return config.Settings{}, err
+	}
+
+	return cfg.ForAddon(filepath.Base(addonDir))
+}
+
+// ocmClient returns the OCM client for the given environment creating
+// it on first use since every environment is backed by a different
+// OCM API.
+func (p *Pipeline) ocmClient(env string) (*validator.OCMClientImpl, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if ocm, ok := p.ocm[env]; ok {
+		return ocm, nil
+	}
+
+	ocm, err := validator.NewOCMClient(
+		validator.WithConnectOptions{
+			validator.WithAPIURL(OCMURL(env)),
+			validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
+			validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
+			validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ocm[env] = ocm
+
+	return ocm, nil
+}
+
+// targetName names the suite of a target whose
+// metadata could not be loaded.
+func targetName(addonDir string, t Target) string {
+	if t.Version == "" {
+		return fmt.Sprintf("%s (%s)", path.Base(addonDir), t.Env)
+	}
+
+	return fmt.Sprintf("%s (%s, %s)", path.Base(addonDir), t.Env, t.Version)
+}
+
+func suiteName(addonDir, env string, meta *v1alpha1.AddonMetadataSpec) string {
+	if meta.ImageSetVersion == nil {
+		return fmt.Sprintf("%s (%s)", path.Base(addonDir), env)
+	}
+
+	return fmt.Sprintf("%s (%s, %s)", path.Base(addonDir), env, *meta.ImageSetVersion)
+}
+
+// metaSources returns the paths of the files from which the given
+// metadata was loaded, relative to the working directory when possible.
+func metaSources(addonDir, env string, meta *v1alpha1.AddonMetadataSpec) []string {
+	sources := []string{utils.MetadataPath(addonDir, env)}
+
+	if meta.ImageSetVersion != nil {
+		sources = append(sources, utils.ImageSetPath(addonDir, env, *meta.ImageSetVersion))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return sources
+	}
+
+	for i, src := range sources {
+		if rel, err := filepath.Rel(wd, src); err == nil {
+			sources[i] = rel
+		}
+	}
+
+	return sources
+}
+
+type Config struct {
+	// Extractor extracts the bundles referenced by addon metadata.
+	Extractor extractor.Extractor
+	// ConfigPath is the path of the validation config file. The
+	// config file is discovered from each addon directory if empty.
+	ConfigPath string
+	// Filter selects the validators to run. The config file's
+	// disabled validators are ignored if a Filter is given.
+	Filter validator.Filter
+	// ExcludedNamespaces are excluded from validation. The config
+	// file's excluded namespaces are ignored if any are given.
+	ExcludedNamespaces []string
+	// Middleware wraps every validator run.
+	Middleware []validator.Middleware
+	// Plugins initialize validators loaded from outside of
+	// this repository in addition to the registered ones.
+	Plugins []validator.Initializer
+	// RegistryClient queries image registries. Validators use an
+	// anonymous client if none is given.
+	RegistryClient validator.RegistryClient
+	// Timeout is the deadline of every validator run unless the
+	// config file sets a timeout for the validator. Panics of
+	// validators are recovered in any case.
+	Timeout time.Duration
+	// Offline disables all network access of validators. Validators
+	// requiring network access are reported as skipped.
+	Offline bool
+	// SkipBundles disables bundle extraction. Validators requiring
+	// addon bundles are reported as skipped.
+	SkipBundles bool
+}
+
+func (c *Config) Option(opts ...Option) {
+	for _, opt := range opts {
+		opt.ConfigurePipeline(c)
+	}
+}
+
+func (c *Config) Default() {
+	if c.Extractor == nil {
+		c.Extractor = extractor.New()
+	}
+
+	if c.Timeout == 0 {
+		c.Timeout = 2 * time.Minute
+	}
+
+	if c.Middleware == nil {
+		c.Middleware = []validator.Middleware{
+			validator.NewRetryMiddleware(),
+		}
+	}
+}
+
+type Option interface {
+	ConfigurePipeline(*Config)
+}
+
+type WithExtractor struct{ extractor.Extractor }
+
+func (w WithExtractor) ConfigurePipeline(c *Config) { c.Extractor = w.Extractor }
+
+type WithConfigPath string
+
+func (w WithConfigPath) ConfigurePipeline(c *Config) { c.ConfigPath = string(w) }
+
+type WithFilter validator.Filter
+
+func (w WithFilter) ConfigurePipeline(c *Config) { c.Filter = validator.Filter(w) }
+
+type WithExcludedNamespaces []string
+
+func (w WithExcludedNamespaces) ConfigurePipeline(c *Config) {
+	c.ExcludedNamespaces = append(c.ExcludedNamespaces, w...)
+}
+
+type WithMiddleware []validator.Middleware
+
+func (w WithMiddleware) ConfigurePipeline(c *Config) { c.Middleware = w }
+
+type WithPlugins []validator.Initializer
+
+func (w WithPlugins) ConfigurePipeline(c *Config) { c.Plugins = append(c.Plugins, w...) }
+
+type WithRegistryClient struct{ validator.RegistryClient }
+
+func (w WithRegistryClient) ConfigurePipeline(c *Config) { c.RegistryClient = w.RegistryClient }
+
+type WithOffline bool
+
+func (w WithOffline) ConfigurePipeline(c *Config) { c.Offline = bool(w) }
+
+type WithSkipBundles bool
+
+func (w WithSkipBundles) ConfigurePipeline(c *Config) { c.SkipBundles = bool(w) }
+
+type WithTimeout time.Duration
+
+func (w WithTimeout) ConfigurePipeline(c *Config) { c.Timeout = time.Duration(w) }
@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineValidateRecordsTargetErrors(t *testing.T) {
+	t.Parallel()
+
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	p := New(
+		WithOffline(true),
+		WithSkipBundles(true),
+	)
+	defer p.Close()
+
+	suites, err := p.Validate(context.Background(), addonDir,
+		Target{Env: "stage", Version: "0.0.1"},
+		Target{Env: "stage", Version: "9.9.9"},
+		Target{Env: "stage", Version: "0.0.2"},
+	)
+	require.NoError(t, err)
+	require.Len(t, suites, 3)
+
+	assert.NoError(t, suites[0].Err)
+	assert.Equal(t, "reference-addon (stage, 0.0.1)", suites[0].Name)
+
+	assert.Error(t, suites[1].Err)
+	assert.Equal(t, "reference-addon (stage, 9.9.9)", suites[1].Name)
+	assert.Empty(t, suites[1].Results)
+
+	assert.NoError(t, suites[2].Err)
+	assert.Equal(t, "reference-addon (stage, 0.0.2)", suites[2].Name)
+}
@@ -0,0 +1,174 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+)
+
+var envToOCMURL = map[string]string{
+	"stage":       "https://api.stage.openshift.com",
+	"integration": "https://api.integration.openshift.com",
+	"production":  "https://api.openshift.com",
+}
+
+// IsValidEnv returns 'true' if the given environment is one of
+// 'integration', 'stage' or 'production'.
+func IsValidEnv(env string) bool {
+	_, ok := envToOCMURL[env]
+
+	return ok
+}
+
+// OCMURL returns the URL of the OCM API of the given environment
+// or an empty string if the environment is not valid.
+func OCMURL(env string) string {
+	return envToOCMURL[env]
+}
+
+// Target identifies a single environment and imageset version
+// of an addon to validate. An empty Version uses the version
+// referenced by the addon metadata.
+type Target struct {
+	Env     string
+	Version string
+}
+
+func (t Target) String() string {
+	if t.Version == "" {
+		return fmt.Sprintf("env %q", t.Env)
+	}
+
+	return fmt.Sprintf("env %q and version %q", t.Env, t.Version)
+}
+
+// Selection describes which targets of an addon are validated.
+type Selection struct {
+	// Env is the environment to validate unless AllEnvs is set.
+	Env string
+	// Version is the imageset version to validate unless
+	// AllVersions is set.
+	Version string
+	// AllEnvs selects every environment present in the addon
+	// directory.
+	AllEnvs bool
+	// AllVersions selects every imageset of each environment.
+	AllVersions bool
+}
+
+// ResolveTargets returns the targets of the addon in addonDir which
+// match the given Selection. Environments without imagesets are
+// validated once when all versions are selected.
+func ResolveTargets(addonDir string, sel Selection) ([]Target, error) {
+	envs := []string{sel.Env}
+
+	if sel.AllEnvs {
+		var err error
+
+		envs, err = utils.Envs(addonDir)
+		if err != nil {
+			return nil, fmt.Errorf("listing environments: %w", err)
+		}
+
+		for _, env := range envs {
+			if !IsValidEnv(env) {
+				return nil, fmt.Errorf("addon directory contains metadata for unknown environment %q", env)
+			}
+		}
+	}
+
+	var targets []Target
+
+	for _, env := range envs {
+		if !sel.AllVersions {
+			targets = append(targets, Target{Env: env, Version: sel.Version})
+
+			continue
+		}
+
+		versions, err := utils.ImageSetVersions(addonDir, env)
+		if err != nil {
+			return nil, fmt.Errorf("listing imageset versions for env %q: %w", env, err)
+		}
+
+		if len(versions) == 0 {
+			targets = append(targets, Target{Env: env})
+
+			continue
+		}
+
+		for _, v := range versions {
+			targets = append(targets, Target{Env: env, Version: v})
+		}
+	}
+
+	return targets, nil
+}
+
+// DiscoverAddons returns the sorted paths of all addon directories
+// below root. An addon directory is any directory containing
+// addon metadata for at least one environment. Hidden directories
+// and the contents of addon directories are not searched.
+func DiscoverAddons(root string) ([]string, error) {
+	var addons []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		isAddon, err := isAddonDir(path)
+		if err != nil {
+			return err
+		}
+
+		if !isAddon {
+			return nil
+		}
+
+		addons = append(addons, path)
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovering addons in %q: %w", root, err)
+	}
+
+	sort.Strings(addons)
+
+	return addons, nil
+}
+
+func isAddonDir(dir string) (bool, error) {
+	info, err := os.Stat(filepath.Join(dir, "metadata"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if !info.IsDir() {
+		return false, nil
+	}
+
+	envs, err := utils.Envs(dir)
+	if err != nil {
+		return false, err
+	}
+
+	return len(envs) > 0, nil
+}
@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTargets(t *testing.T) {
+	t.Parallel()
+
+	imageSetDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+	indexImageDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "reference-addon")
+
+	for name, tc := range map[string]struct {
+		AddonDir  string
+		Selection Selection
+		Expected  []Target
+	}{
+		"single target": {
+			AddonDir:  imageSetDir,
+			Selection: Selection{Env: "stage", Version: "0.0.1"},
+			Expected: []Target{
+				{Env: "stage", Version: "0.0.1"},
+			},
+		},
+		"all envs": {
+			AddonDir:  imageSetDir,
+			Selection: Selection{Version: "latest", AllEnvs: true},
+			Expected: []Target{
+				{Env: "stage", Version: "latest"},
+			},
+		},
+		"all versions": {
+			AddonDir:  imageSetDir,
+			Selection: Selection{Env: "stage", AllVersions: true},
+			Expected: []Target{
+				{Env: "stage", Version: "0.0.1"},
+				{Env: "stage", Version: "0.0.2"},
+				{Env: "stage", Version: "0.0.5"},
+			},
+		},
+		"all versions without imagesets": {
+			AddonDir:  indexImageDir,
+			Selection: Selection{AllEnvs: true, AllVersions: true},
+			Expected: []Target{
+				{Env: "stage"},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			targets, err := ResolveTargets(tc.AddonDir, tc.Selection)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, targets)
+		})
+	}
+}
+
+func TestResolveTargetsRejectsUnknownEnv(t *testing.T) {
+	t.Parallel()
+
+	addonDir := t.TempDir()
+	writeMetadata(t, addonDir, "qa")
+
+	_, err := ResolveTargets(addonDir, Selection{AllEnvs: true})
+	assert.Error(t, err)
+}
+
+func TestDiscoverAddons(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	writeMetadata(t, filepath.Join(root, "addons", "first-addon"), "stage")
+	writeMetadata(t, filepath.Join(root, "addons", "second-addon"), "production")
+	writeMetadata(t, filepath.Join(root, ".hidden", "hidden-addon"), "stage")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "addons", "empty-addon", "metadata"), 0o755))
+
+	addons, err := DiscoverAddons(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "addons", "first-addon"),
+		filepath.Join(root, "addons", "second-addon"),
+	}, addons)
+}
+
+func writeMetadata(t *testing.T, addonDir, env string) {
+	t.Helper()
+
+	dir := filepath.Join(addonDir, "metadata", env)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "addon.yaml"), []byte("id: test\n"), 0o644))
+}
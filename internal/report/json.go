@@ -0,0 +1,127 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// JSONWriter serializes a Report as an indented JSON document.
+type JSONWriter struct{}
+
+func (w JSONWriter) Write(out io.Writer, r Report) error {
+	doc := jsonReport{
+		Suites: make([]jsonSuite, 0, len(r.Suites)),
+	}
+
+	for _, s := range r.Suites {
+		suite := jsonSuite{
+			Name:    s.Name,
+			Sources: s.Sources,
+			Results: make([]jsonResult, 0, len(s.Results)),
+		}
+
+		if s.Err != nil {
+			suite.Error = s.Err.Error()
+		}
+
+		for _, res := range s.Results {
+			suite.Results = append(suite.Results, newJSONResult(res))
+		}
+
+		for _, sup := range s.ExpiredSuppressions {
+			suite.ExpiredSuppressions = append(suite.ExpiredSuppressions, newJSONSuppression(sup))
+		}
+
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	return nil
+}
+
+type jsonReport struct {
+	Suites []jsonSuite `json:"suites"`
+}
+
+type jsonSuite struct {
+	Name                string            `json:"name"`
+	Sources             []string          `json:"sources,omitempty"`
+	Results             []jsonResult      `json:"results"`
+	ExpiredSuppressions []jsonSuppression `json:"expiredSuppressions,omitempty"`
+	Error               string            `json:"error,omitempty"`
+}
+
+type jsonResult struct {
+	Code           string              `json:"code"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Severity       string              `json:"severity"`
+	Status         status              `json:"status"`
+	FailureMsgs    []string            `json:"failureMessages,omitempty"`
+	SuppressedMsgs []jsonSuppressedMsg `json:"suppressedMessages,omitempty"`
+	Error          string              `json:"error,omitempty"`
+	SkipReason     string              `json:"skipReason,omitempty"`
+}
+
+type jsonSuppressedMsg struct {
+	Message     string          `json:"message"`
+	Suppression jsonSuppression `json:"suppression"`
+}
+
+type jsonSuppression struct {
+	Code          string `json:"code"`
+	Message       string `json:"message,omitempty"`
+	Justification string `json:"justification"`
+	Expires       string `json:"expires"`
+}
+
+func newJSONSuppression(sup validator.Suppression) jsonSuppression {
+	js := jsonSuppression{
+		Code:          sup.Code.String(),
+		Justification: sup.Justification,
+		Expires:       sup.Expires.Format(time.DateOnly),
+	}
+
+	if sup.Message != nil {
+		js.Message = sup.Message.String()
+	}
+
+	return js
+}
+
+func newJSONResult(res validator.Result) jsonResult {
+	jr := jsonResult{
+		Code:        res.Code.String(),
+		Name:        res.Name,
+		Description: res.Description,
+		Severity:    res.Severity.String(),
+		Status:      statusOf(res),
+	}
+
+	if res.IsError() {
+		jr.Error = res.Error.Error()
+	} else if res.IsSkipped() {
+		jr.SkipReason = res.SkipReason
+	} else if !res.IsSuccess() {
+		jr.FailureMsgs = res.FailureMsgs
+	}
+
+	for _, msg := range res.SuppressedMsgs {
+		jr.SuppressedMsgs = append(jr.SuppressedMsgs, jsonSuppressedMsg{
+			Message:     msg.Msg,
+			Suppression: newJSONSuppression(msg.Suppression),
+		})
+	}
+
+	return jr
+}
@@ -0,0 +1,204 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// JUnitWriter serializes a Report as JUnit XML. Every suite is
+// written as a testsuite and every validator as a testcase
+// identified by its Code. Suppressed failures are reported as
+// skipped testcases unless unsuppressed failures remain in which
+// case they are listed in the testcase's system-out. Suites which
+// could not be validated hold a single errored testcase.
+type JUnitWriter struct {
+	// FailOn is the lowest severity reported as a testcase
+	// failure. Failures of a lower severity are listed in the
+	// testcase's system-out instead.
+	FailOn validator.Severity
+}
+
+func (w JUnitWriter) Write(out io.Writer, r Report) error {
+	doc := junitTestSuites{
+		Name: "mtcli validate",
+	}
+
+	for _, s := range r.Suites {
+		suite := junitTestSuite{
+			Name:  s.Name,
+			Tests: len(s.Results),
+		}
+
+		if len(s.Sources) > 0 || len(s.ExpiredSuppressions) > 0 {
+			suite.Properties = &junitProperties{}
+
+			for _, src := range s.Sources {
+				suite.Properties.Properties = append(suite.Properties.Properties,
+					junitProperty{Name: "source", Value: src},
+				)
+			}
+
+			for _, sup := range s.ExpiredSuppressions {
+				suite.Properties.Properties = append(suite.Properties.Properties,
+					junitProperty{Name: "expired-suppression", Value: sup.String()},
+				)
+			}
+		}
+
+		if s.Err != nil {
+			suite.Tests++
+			suite.Errors++
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: toolName,
+				Name:      "validate",
+				Error: &junitMessage{
+					Message: s.Err.Error(),
+					Body:    s.Err.Error(),
+				},
+			})
+		}
+
+		for _, res := range s.Results {
+			tc := newJUnitTestCase(res, w.FailOn)
+
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+
+			if tc.Error != nil {
+				suite.Errors++
+			}
+
+			if tc.Skipped != nil {
+				suite.Skipped++
+			}
+
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		doc.TestSuites = append(doc.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return fmt.Errorf("writing xml header: %w", err)
+	}
+
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+func newJUnitTestCase(res validator.Result, failOn validator.Severity) junitTestCase {
+	tc := junitTestCase{
+		ClassName: res.Code.String(),
+		Name:      res.Name,
+		Time:      fmt.Sprintf("%.3f", res.Duration.Seconds()),
+	}
+
+	switch statusOf(res) {
+	case statusError:
+		tc.Error = &junitMessage{
+			Message: res.Error.Error(),
+			Body:    res.Error.Error(),
+		}
+	case statusFailed:
+		var out []string
+
+		if res.Severity >= failOn {
+			tc.Failure = &junitMessage{
+				Message: res.Description,
+				Type:    res.Severity.String(),
+				Body:    strings.Join(res.FailureMsgs, "\n"),
+			}
+		} else {
+			for _, msg := range res.FailureMsgs {
+				out = append(out, fmt.Sprintf("%s: %s", res.Severity, msg))
+			}
+		}
+
+		tc.SystemOut = strings.Join(append(out, junitSuppressedMsgs(res)...), "\n")
+	case statusSkipped:
+		tc.Skipped = &junitMessage{
+			Message: res.SkipReason,
+		}
+	case statusSuppressed:
+		tc.Skipped = &junitMessage{
+			Message: "suppressed",
+			Body:    strings.Join(junitSuppressedMsgs(res), "\n"),
+		}
+	}
+
+	return tc
+}
+
+func junitSuppressedMsgs(res validator.Result) []string {
+	msgs := make([]string, 0, len(res.SuppressedMsgs))
+
+	for _, msg := range res.SuppressedMsgs {
+		msgs = append(msgs, suppressedMessage(msg))
+	}
+
+	return msgs
+}
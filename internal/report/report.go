@@ -0,0 +1,228 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// Report is a collection of validation results grouped by the
+// addon metadata they were produced from.
+type Report struct {
+	Suites []Suite
+}
+
+// HasFailure returns 'true' if any suite in the report contains
+// a failed or errored result or could not be validated.
+func (r Report) HasFailure() bool {
+	for _, s := range r.Suites {
+		if s.Err != nil || s.Results.HasFailure() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasFailureAtOrAbove returns 'true' if any suite in the report
+// contains a failed result with a severity at or above the given
+// severity.
+func (r Report) HasFailureAtOrAbove(sev validator.Severity) bool {
+	for _, s := range r.Suites {
+		if s.Results.HasFailureAtOrAbove(sev) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSkipped returns 'true' if any suite in the report
+// contains a skipped result.
+func (r Report) HasSkipped() bool {
+	for _, s := range r.Suites {
+		if s.Results.HasSkipped() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Errors returns the errors of every suite in the report
+// including the errors of suites which could not be validated.
+func (r Report) Errors() []error {
+	var errs []error
+
+	for _, s := range r.Suites {
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name, s.Err))
+		}
+
+		errs = append(errs, s.Results.Errors()...)
+	}
+
+	return errs
+}
+
+// Suite holds the results of validating a single types.MetaBundle
+// along with the files the addon metadata was loaded from.
+type Suite struct {
+	// Name is a human readable identifier for the suite.
+	Name string
+	// Sources are the paths of the files from which the
+	// validated addon metadata was loaded.
+	Sources []string
+	// MetaBundle is the validated addon metadata along
+	// with the bundles of its operator.
+	MetaBundle types.MetaBundle
+	// Results are the results of all validators which ran
+	// against the addon metadata.
+	Results validator.ResultList
+	// ExpiredSuppressions are suppressions which were configured
+	// for the addon but no longer apply.
+	ExpiredSuppressions []validator.Suppression
+	// Err is set if the addon metadata could not be validated
+	// in which case no Results are present.
+	Err error
+}
+
+// Writer serializes a Report to an io.Writer.
+type Writer interface {
+	Write(io.Writer, Report) error
+}
+
+// Format is the name of a supported output format.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+	FormatSARIF Format = "sarif"
+	// FormatStatusYAML emits the status schema of the
+	// AddonMetadata and AddonImageSet resources.
+	FormatStatusYAML Format = "status-yaml"
+)
+
+// Formats returns all supported output formats.
+func Formats() []Format {
+	return []Format{
+		FormatTable,
+		FormatJSON,
+		FormatJUnit,
+		FormatSARIF,
+		FormatStatusYAML,
+	}
+}
+
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// ParseFormat converts the given string to a Format.
+// An error is returned if the format is not supported.
+func ParseFormat(maybeFormat string) (Format, error) {
+	for _, f := range Formats() {
+		if strings.EqualFold(string(f), maybeFormat) {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%q: %w", maybeFormat, ErrUnknownFormat)
+}
+
+// NewWriter returns the Writer implementation for the given Format.
+func NewWriter(f Format, opts ...WriterOption) (Writer, error) {
+	var cfg WriterConfig
+
+	cfg.Option(opts...)
+
+	switch f {
+	case FormatTable:
+		return TableWriter{Timings: cfg.Timings}, nil
+	case FormatJSON:
+		return JSONWriter{}, nil
+	case FormatJUnit:
+		return JUnitWriter{FailOn: cfg.FailOn}, nil
+	case FormatSARIF:
+		return SARIFWriter{}, nil
+	case FormatStatusYAML:
+		return StatusYAMLWriter{}, nil
+	default:
+		return nil, fmt.Errorf("%q: %w", f, ErrUnknownFormat)
+	}
+}
+
+type WriterConfig struct {
+	// Timings renders the duration of validator runs
+	// in formats which don't always include them.
+	Timings bool
+	// FailOn is the lowest severity of failures which fail
+	// validation in formats which distinguish them.
+	FailOn validator.Severity
+}
+
+func (c *WriterConfig) Option(opts ...WriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureWriter(c)
+	}
+}
+
+type WriterOption interface {
+	ConfigureWriter(*WriterConfig)
+}
+
+type WithTimings bool
+
+func (w WithTimings) ConfigureWriter(c *WriterConfig) { c.Timings = bool(w) }
+
+type WithFailOn validator.Severity
+
+func (w WithFailOn) ConfigureWriter(c *WriterConfig) { c.FailOn = validator.Severity(w) }
+
+const wikiURL = "https://github.com/mt-sre/addon-metadata-operator/wiki"
+
+func helpURI(code validator.Code) string {
+	return fmt.Sprintf("%s/%s", wikiURL, code)
+}
+
+type status string
+
+const (
+	statusSuccess    status = "success"
+	statusFailed     status = "failed"
+	statusError      status = "error"
+	statusSuppressed status = "suppressed"
+	statusSkipped    status = "skipped"
+)
+
+func statusOf(res validator.Result) status {
+	switch {
+	case res.IsSuccess():
+		return statusSuccess
+	case res.IsSkipped():
+		return statusSkipped
+	case res.IsError():
+		return statusError
+	case res.IsSuppressed():
+		return statusSuppressed
+	default:
+		return statusFailed
+	}
+}
+
+func toURI(path string) string {
+	return filepath.ToSlash(path)
+}
+
+func suppressedMessage(msg validator.SuppressedMsg) string {
+	return fmt.Sprintf("%s (suppressed: %s)", msg.Msg, msg.Suppression.Justification)
+}
+
+func expiredSuppressionMessage(sup validator.Suppression) string {
+	return fmt.Sprintf("suppression expired: %s", sup)
+}
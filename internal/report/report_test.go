@@ -0,0 +1,705 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input          string
+		Expected       Format
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"table": {
+			Input:          "table",
+			Expected:       FormatTable,
+			ErrorAssertion: assert.NoError,
+		},
+		"upper case": {
+			Input:          "JSON",
+			Expected:       FormatJSON,
+			ErrorAssertion: assert.NoError,
+		},
+		"junit": {
+			Input:          "junit",
+			Expected:       FormatJUnit,
+			ErrorAssertion: assert.NoError,
+		},
+		"sarif": {
+			Input:          "sarif",
+			Expected:       FormatSARIF,
+			ErrorAssertion: assert.NoError,
+		},
+		"status-yaml": {
+			Input:          "status-yaml",
+			Expected:       FormatStatusYAML,
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown": {
+			Input:          "yaml",
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ParseFormat(tc.Input)
+			tc.ErrorAssertion(t, err)
+
+			assert.Equal(t, tc.Expected, f)
+		})
+	}
+}
+
+func TestWritersSupportAllFormats(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range Formats() {
+		w, err := NewWriter(f)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+
+		require.NoError(t, w.Write(&buf, testReport(t)))
+		assert.NotEmpty(t, buf.String())
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JSONWriter{}.Write(&buf, testReport(t)))
+
+	var doc jsonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	require.Len(t, doc.Suites, 1)
+
+	suite := doc.Suites[0]
+	assert.Equal(t, "reference-addon (stage)", suite.Name)
+	assert.Equal(t, []string{"metadata/stage/addon.yaml"}, suite.Sources)
+	require.Len(t, suite.Results, 3)
+
+	assert.Equal(t, "AM0001", suite.Results[0].Code)
+	assert.Equal(t, statusSuccess, suite.Results[0].Status)
+
+	assert.Equal(t, "AM0002", suite.Results[1].Code)
+	assert.Equal(t, statusFailed, suite.Results[1].Status)
+	assert.Equal(t, "warning", suite.Results[1].Severity)
+	assert.Equal(t, []string{"first", "second"}, suite.Results[1].FailureMsgs)
+
+	assert.Equal(t, "AM0003", suite.Results[2].Code)
+	assert.Equal(t, statusError, suite.Results[2].Status)
+	assert.Equal(t, "boom", suite.Results[2].Error)
+}
+
+func TestJUnitWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, JUnitWriter{}.Write(&buf, testReport(t)))
+
+	var doc junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, 3, doc.Tests)
+	assert.Equal(t, 1, doc.Failures)
+	assert.Equal(t, 1, doc.Errors)
+
+	require.Len(t, doc.TestSuites, 1)
+	require.Len(t, doc.TestSuites[0].TestCases, 3)
+
+	cases := doc.TestSuites[0].TestCases
+	assert.Equal(t, "AM0001", cases[0].ClassName)
+	assert.Nil(t, cases[0].Failure)
+	assert.Nil(t, cases[0].Error)
+
+	require.NotNil(t, cases[1].Failure)
+	assert.Equal(t, "first\nsecond", cases[1].Failure.Body)
+
+	require.NotNil(t, cases[2].Error)
+	assert.Equal(t, "boom", cases[2].Error.Message)
+}
+
+func TestJUnitWriterFailOn(t *testing.T) {
+	t.Parallel()
+
+	w, err := NewWriter(FormatJUnit, WithFailOn(validator.SeverityError))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, w.Write(&buf, testReport(t)))
+
+	var doc junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, 0, doc.Failures)
+	assert.Equal(t, 1, doc.Errors)
+
+	cases := doc.TestSuites[0].TestCases
+	require.Len(t, cases, 3)
+
+	assert.Nil(t, cases[1].Failure)
+	assert.Equal(t, "warning: first\nwarning: second", cases[1].SystemOut)
+}
+
+func TestSARIFWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, SARIFWriter{}.Write(&buf, testReport(t)))
+
+	var doc sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, sarifVersion, doc.Version)
+	require.Len(t, doc.Runs, 1)
+
+	run := doc.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, 3)
+	require.Len(t, run.Results, 2)
+
+	for _, res := range run.Results {
+		assert.Equal(t, "AM0002", res.RuleID)
+		assert.Equal(t, 1, res.RuleIndex)
+		assert.Equal(t, "warning", res.Level)
+		require.Len(t, res.Locations, 1)
+		assert.Equal(t, "metadata/stage/addon.yaml", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+
+	require.Len(t, run.Invocations, 1)
+	assert.False(t, run.Invocations[0].ExecutionSuccessful)
+	assert.Len(t, run.Invocations[0].ToolExecutionNotifications, 1)
+}
+
+func TestStatusYAMLWriter(t *testing.T) {
+	t.Parallel()
+
+	r := testReport(t)
+	r.Suites = append(r.Suites, Suite{
+		Name: "reference-addon (production)",
+		Results: validator.ResultList{
+			newTestBase(t, 1).Fail("broken"),
+		},
+	})
+
+	var buf bytes.Buffer
+
+	require.NoError(t, StatusYAMLWriter{}.Write(&buf, r))
+
+	docs := strings.Split(buf.String(), "---\n")
+	require.Len(t, docs, 2)
+
+	var doc statusDocument
+	require.NoError(t, yaml.Unmarshal([]byte(docs[0]), &doc))
+
+	assert.Equal(t, "reference-addon (stage)", doc.Name)
+	require.Len(t, doc.Status.Results, 3)
+	assert.Equal(t, v1alpha1.ValidatorResultSuccess, doc.Status.Results[0].Status)
+	assert.Equal(t, v1alpha1.ValidatorResultFailure, doc.Status.Results[1].Status)
+	assert.Equal(t, []string{"first", "second"}, doc.Status.Results[1].FailureMsgs)
+	assert.Equal(t, v1alpha1.ValidatorResultError, doc.Status.Results[2].Status)
+	assert.Equal(t, "boom", doc.Status.Results[2].Error)
+
+	require.Len(t, doc.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ConditionValidated, doc.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionUnknown, doc.Status.Conditions[0].Status)
+
+	require.NoError(t, yaml.Unmarshal([]byte(docs[1]), &doc))
+
+	assert.Equal(t, "reference-addon (production)", doc.Name)
+	assert.Equal(t, metav1.ConditionFalse, doc.Status.Conditions[0].Status)
+}
+
+func TestReportHasFailureAtOrAbove(t *testing.T) {
+	t.Parallel()
+
+	r := testReport(t)
+
+	assert.True(t, r.HasFailureAtOrAbove(validator.SeverityWarning))
+	assert.False(t, r.HasFailureAtOrAbove(validator.SeverityError))
+}
+
+func TestWritersReportSuppressions(t *testing.T) {
+	t.Parallel()
+
+	sup := validator.Suppression{
+		Code:          2,
+		Justification: "accepted",
+		Expires:       time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	expired := validator.Suppression{
+		Code:          3,
+		Justification: "no longer accepted",
+		Expires:       time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	suppressed := newTestBase(t, 2).Fail("first")
+	suppressed.SuppressedMsgs = []validator.SuppressedMsg{{Msg: "first", Suppression: sup}}
+	suppressed.FailureMsgs = nil
+
+	r := Report{
+		Suites: []Suite{
+			{
+				Name:                "reference-addon (stage)",
+				Results:             validator.ResultList{suppressed},
+				ExpiredSuppressions: []validator.Suppression{expired},
+			},
+		},
+	}
+
+	assert.False(t, r.HasFailure())
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JSONWriter{}.Write(&buf, r))
+
+		var doc jsonReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		require.Len(t, doc.Suites, 1)
+		require.Len(t, doc.Suites[0].Results, 1)
+
+		res := doc.Suites[0].Results[0]
+		assert.Equal(t, statusSuppressed, res.Status)
+		require.Len(t, res.SuppressedMsgs, 1)
+		assert.Equal(t, "first", res.SuppressedMsgs[0].Message)
+		assert.Equal(t, "accepted", res.SuppressedMsgs[0].Suppression.Justification)
+		assert.Equal(t, "2030-01-01", res.SuppressedMsgs[0].Suppression.Expires)
+
+		require.Len(t, doc.Suites[0].ExpiredSuppressions, 1)
+		assert.Equal(t, "AM0003", doc.Suites[0].ExpiredSuppressions[0].Code)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JUnitWriter{}.Write(&buf, r))
+
+		var doc junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+		assert.Equal(t, 0, doc.Failures)
+		assert.Equal(t, 1, doc.Skipped)
+		require.Len(t, doc.TestSuites, 1)
+		require.NotNil(t, doc.TestSuites[0].TestCases[0].Skipped)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, SARIFWriter{}.Write(&buf, r))
+
+		var doc sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		run := doc.Runs[0]
+		require.Len(t, run.Results, 1)
+		require.Len(t, run.Results[0].Suppressions, 1)
+		assert.Equal(t, "accepted", run.Results[0].Suppressions[0].Justification)
+
+		require.Len(t, run.Invocations, 1)
+		assert.True(t, run.Invocations[0].ExecutionSuccessful)
+		assert.Len(t, run.Invocations[0].ToolExecutionNotifications, 1)
+	})
+}
+
+func TestWritersReportPartlySuppressed(t *testing.T) {
+	t.Parallel()
+
+	sup := validator.Suppression{
+		Code:          2,
+		Message:       regexp.MustCompile("^accepted"),
+		Justification: "known issue",
+		Expires:       time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	results, _ := validator.ResultList{
+		newTestBase(t, 2).Fail("accepted failure", "new failure"),
+	}.Suppress(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), sup)
+
+	r := Report{
+		Suites: []Suite{
+			{
+				Name:    "reference-addon (stage)",
+				Results: results,
+			},
+		},
+	}
+
+	require.Len(t, results, 1)
+	assert.True(t, r.HasFailure())
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JSONWriter{}.Write(&buf, r))
+
+		var doc jsonReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		require.Len(t, doc.Suites[0].Results, 1)
+
+		res := doc.Suites[0].Results[0]
+		assert.Equal(t, statusFailed, res.Status)
+		assert.Equal(t, []string{"new failure"}, res.FailureMsgs)
+		require.Len(t, res.SuppressedMsgs, 1)
+		assert.Equal(t, "accepted failure", res.SuppressedMsgs[0].Message)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JUnitWriter{}.Write(&buf, r))
+
+		var doc junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+		require.Len(t, doc.TestSuites[0].TestCases, 1)
+
+		tc := doc.TestSuites[0].TestCases[0]
+		require.NotNil(t, tc.Failure)
+		assert.Equal(t, "new failure", tc.Failure.Body)
+		assert.Contains(t, tc.SystemOut, "accepted failure (suppressed: known issue)")
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, SARIFWriter{}.Write(&buf, r))
+
+		var doc sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		run := doc.Runs[0]
+		require.Len(t, run.Tool.Driver.Rules, 1)
+		require.Len(t, run.Results, 2)
+		assert.Empty(t, run.Results[0].Suppressions)
+		require.Len(t, run.Results[1].Suppressions, 1)
+		assert.Equal(t, "known issue", run.Results[1].Suppressions[0].Justification)
+	})
+
+	t.Run("status-yaml", func(t *testing.T) {
+		t.Parallel()
+
+		var status v1alpha1.ValidationStatus
+
+		validator.SetValidationStatus(&status, types.MetaBundle{}, results, 1)
+
+		require.Len(t, status.Results, 1)
+		assert.Equal(t, v1alpha1.ValidatorResultFailure, status.Results[0].Status)
+		assert.Equal(t, []string{"new failure"}, status.Results[0].FailureMsgs)
+		assert.Equal(t, []string{"accepted failure"}, status.Results[0].SuppressedMsgs)
+	})
+}
+
+func TestTableWriterTimings(t *testing.T) {
+	t.Parallel()
+
+	r := testReport(t)
+	r.Suites[0].Results[0].Duration = 1234567 * time.Microsecond
+
+	for name, tc := range map[string]struct {
+		Options          []WriterOption
+		ExpectedDuration bool
+	}{
+		"without timings": {},
+		"with timings": {
+			Options:          []WriterOption{WithTimings(true)},
+			ExpectedDuration: true,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w, err := NewWriter(FormatTable, tc.Options...)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+
+			require.NoError(t, w.Write(&buf, r))
+
+			if tc.ExpectedDuration {
+				assert.Contains(t, buf.String(), "DURATION")
+				assert.Contains(t, buf.String(), "1.235s")
+			} else {
+				assert.NotContains(t, buf.String(), "DURATION")
+			}
+		})
+	}
+}
+
+func TestWritersReportSuiteErrors(t *testing.T) {
+	t.Parallel()
+
+	r := testReport(t)
+	r.Suites = append(r.Suites, Suite{
+		Name: "reference-addon (production)",
+		Err:  errors.New("extracting bundles: unreachable"),
+	})
+
+	assert.True(t, r.HasFailure())
+	require.Len(t, r.Errors(), 2)
+	assert.EqualError(t, r.Errors()[1], "reference-addon (production): extracting bundles: unreachable")
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, TableWriter{}.Write(&buf, r))
+
+		assert.Contains(t, buf.String(), "reference-addon (stage)")
+		assert.Contains(t, buf.String(), "Error: extracting bundles: unreachable")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JSONWriter{}.Write(&buf, r))
+
+		var doc jsonReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		require.Len(t, doc.Suites, 2)
+		assert.Len(t, doc.Suites[0].Results, 3)
+		assert.Empty(t, doc.Suites[1].Results)
+		assert.Equal(t, "extracting bundles: unreachable", doc.Suites[1].Error)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JUnitWriter{}.Write(&buf, r))
+
+		var doc junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+		assert.Equal(t, 2, doc.Errors)
+		require.Len(t, doc.TestSuites, 2)
+		require.Len(t, doc.TestSuites[1].TestCases, 1)
+		require.NotNil(t, doc.TestSuites[1].TestCases[0].Error)
+		assert.Equal(t, "extracting bundles: unreachable", doc.TestSuites[1].TestCases[0].Error.Message)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, SARIFWriter{}.Write(&buf, r))
+
+		var doc sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		invocation := doc.Runs[0].Invocations[0]
+		assert.False(t, invocation.ExecutionSuccessful)
+		require.Len(t, invocation.ToolExecutionNotifications, 2)
+		assert.Equal(t, "reference-addon (production): extracting bundles: unreachable",
+			invocation.ToolExecutionNotifications[1].Message.Text,
+		)
+	})
+
+	t.Run("status-yaml", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, StatusYAMLWriter{}.Write(&buf, r))
+
+		docs := strings.Split(buf.String(), "---\n")
+		require.Len(t, docs, 2)
+
+		var doc statusDocument
+		require.NoError(t, yaml.Unmarshal([]byte(docs[1]), &doc))
+
+		require.Len(t, doc.Status.Conditions, 1)
+		assert.Equal(t, metav1.ConditionUnknown, doc.Status.Conditions[0].Status)
+		assert.Equal(t, v1alpha1.ReasonValidationErrored, doc.Status.Conditions[0].Reason)
+		assert.Empty(t, doc.Status.Results)
+	})
+}
+
+func TestWritersReportSkipped(t *testing.T) {
+	t.Parallel()
+
+	r := Report{
+		Suites: []Suite{
+			{
+				Name:    "reference-addon (stage)",
+				Results: validator.ResultList{skippedResult(t, 5, "requires network access")},
+			},
+		},
+	}
+
+	assert.False(t, r.HasFailure())
+	assert.True(t, r.HasSkipped())
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, TableWriter{}.Write(&buf, r))
+
+		assert.Contains(t, buf.String(), "Skipped")
+		assert.Contains(t, buf.String(), "requires network access")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JSONWriter{}.Write(&buf, r))
+
+		var doc jsonReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		require.Len(t, doc.Suites, 1)
+		require.Len(t, doc.Suites[0].Results, 1)
+
+		res := doc.Suites[0].Results[0]
+		assert.Equal(t, statusSkipped, res.Status)
+		assert.Equal(t, "requires network access", res.SkipReason)
+		assert.Empty(t, res.FailureMsgs)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, JUnitWriter{}.Write(&buf, r))
+
+		var doc junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+		assert.Equal(t, 1, doc.Skipped)
+		require.Len(t, doc.TestSuites, 1)
+		require.NotNil(t, doc.TestSuites[0].TestCases[0].Skipped)
+		assert.Equal(t, "requires network access", doc.TestSuites[0].TestCases[0].Skipped.Message)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, SARIFWriter{}.Write(&buf, r))
+
+		var doc sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		assert.Empty(t, doc.Runs[0].Results)
+		assert.True(t, doc.Runs[0].Invocations[0].ExecutionSuccessful)
+
+		notifications := doc.Runs[0].Invocations[0].ToolExecutionNotifications
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "note", notifications[0].Level)
+		assert.Contains(t, notifications[0].Message.Text, "requires network access")
+		require.NotNil(t, notifications[0].AssociatedRule)
+		assert.Equal(t, "AM0005", notifications[0].AssociatedRule.ID)
+	})
+}
+
+func testReport(t *testing.T) Report {
+	t.Helper()
+
+	return Report{
+		Suites: []Suite{
+			{
+				Name:    "reference-addon (stage)",
+				Sources: []string{"metadata/stage/addon.yaml"},
+				Results: validator.ResultList{
+					newTestBase(t, 1).Success(),
+					newTestBase(t, 2, validator.BaseSeverity(validator.SeverityWarning)).Fail("first", "second"),
+					newTestBase(t, 3).Error(errors.New("boom")),
+				},
+			},
+		},
+	}
+}
+
+func newTestBase(t *testing.T, code validator.Code, opts ...validator.BaseOption) *validator.Base {
+	t.Helper()
+
+	base, err := validator.NewBase(code, opts...)
+	require.NoError(t, err)
+
+	return base
+}
+
+// skippedResult returns the result of a validator skipped by a
+// runner with the given reason.
+func skippedResult(t *testing.T, code validator.Code, reason string) validator.Result {
+	t.Helper()
+
+	base := newTestBase(t, code)
+
+	runner, err := validator.NewRunner(
+		validator.WithInitializers{
+			func(validator.Dependencies) (validator.Validator, error) {
+				return testValidator{Base: base}, nil
+			},
+		},
+		validator.WithSkipped{
+			Filter: validator.MatchesCodes(code),
+			Reason: reason,
+		},
+	)
+	require.NoError(t, err)
+
+	return <-runner.Run(context.Background(), types.MetaBundle{})
+}
+
+type testValidator struct{ *validator.Base }
+
+func (v testValidator) Run(context.Context, types.MetaBundle) validator.Result {
+	return v.Success()
+}
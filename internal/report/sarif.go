@@ -0,0 +1,255 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "mtcli"
+	toolURI      = "https://github.com/mt-sre/addon-metadata-operator"
+)
+
+// SARIFWriter serializes a Report as a SARIF 2.1.0 log. Each
+// validator is described as a rule and every failure message
+// is reported as a result located in the suite's source files.
+// Validator errors, skipped validators and suites which could not
+// be validated are reported as tool execution notifications since
+// they do not describe a finding in the addon metadata.
+// Suppressed failures are reported as results carrying an
+// external suppression and expired suppressions are reported
+// as warning notifications.
+type SARIFWriter struct{}
+
+func (w SARIFWriter) Write(out io.Writer, r Report) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	invocation := sarifInvocation{
+		ExecutionSuccessful: true,
+	}
+
+	seenRules := make(map[validator.Code]int)
+
+	for _, s := range r.Suites {
+		locations := make([]sarifLocation, 0, len(s.Sources))
+
+		for _, src := range s.Sources {
+			locations = append(locations, sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: toURI(src)},
+				},
+			})
+		}
+
+		if s.Err != nil {
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications,
+				sarifNotification{
+					Level:   "error",
+					Message: sarifMessage{Text: fmt.Sprintf("%s: %v", s.Name, s.Err)},
+				},
+			)
+		}
+
+		for _, sup := range s.ExpiredSuppressions {
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications,
+				sarifNotification{
+					Level:   "warning",
+					Message: sarifMessage{Text: fmt.Sprintf("%s: %s", s.Name, expiredSuppressionMessage(sup))},
+				},
+			)
+		}
+
+		for _, res := range s.Results {
+			idx, ok := seenRules[res.Code]
+			if !ok {
+				idx = len(run.Tool.Driver.Rules)
+				seenRules[res.Code] = idx
+
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(res))
+			}
+
+			switch statusOf(res) {
+			case statusError:
+				invocation.ExecutionSuccessful = false
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications,
+					sarifNotification{
+						Level:   "error",
+						Message: sarifMessage{Text: fmt.Sprintf("%s: %s: %v", s.Name, res.Code, res.Error)},
+					},
+				)
+			case statusSkipped:
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications,
+					sarifNotification{
+						Level:   "note",
+						Message: sarifMessage{Text: fmt.Sprintf("%s: %s: skipped: %s", s.Name, res.Code, res.SkipReason)},
+						AssociatedRule: &sarifRuleReference{
+							ID:    res.Code.String(),
+							Index: idx,
+						},
+					},
+				)
+			case statusFailed, statusSuppressed:
+				for _, msg := range res.FailureMsgs {
+					run.Results = append(run.Results, sarifResult{
+						RuleID:    res.Code.String(),
+						RuleIndex: idx,
+						Level:     sarifLevel(res.Severity),
+						Message:   sarifMessage{Text: msg},
+						Locations: locations,
+					})
+				}
+
+				for _, msg := range res.SuppressedMsgs {
+					run.Results = append(run.Results, sarifResult{
+						RuleID:    res.Code.String(),
+						RuleIndex: idx,
+						Level:     sarifLevel(res.Severity),
+						Message:   sarifMessage{Text: msg.Msg},
+						Locations: locations,
+						Suppressions: []sarifSuppression{
+							{
+								Kind:          "external",
+								Justification: msg.Suppression.Justification,
+							},
+						},
+					})
+				}
+			}
+		}
+	}
+
+	run.Invocations = []sarifInvocation{invocation}
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	return nil
+}
+
+func newSARIFRule(res validator.Result) sarifRule {
+	return sarifRule{
+		ID:               res.Code.String(),
+		Name:             res.Name,
+		ShortDescription: sarifMessage{Text: res.Description},
+		HelpURI:          helpURI(res.Code),
+		DefaultConfiguration: sarifConfiguration{
+			Level: sarifLevel(res.Severity),
+		},
+	}
+}
+
+func sarifLevel(sev validator.Severity) string {
+	switch sev {
+	case validator.SeverityInfo:
+		return "note"
+	case validator.SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level          string              `json:"level"`
+	Message        sarifMessage        `json:"message"`
+	AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+}
+
+type sarifRuleReference struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
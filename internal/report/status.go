@@ -0,0 +1,60 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// StatusYAMLWriter serializes a Report as a stream of YAML documents,
+// one per suite, holding the status the operator would record for
+// the validated addon.
+type StatusYAMLWriter struct{}
+
+func (w StatusYAMLWriter) Write(out io.Writer, r Report) error {
+	for i, s := range r.Suites {
+		doc := statusDocument{
+			Name:    s.Name,
+			Sources: s.Sources,
+		}
+
+		if s.Err != nil {
+			apimeta.SetStatusCondition(&doc.Status.Conditions, metav1.Condition{
+				Type:    v1alpha1.ConditionValidated,
+				Status:  metav1.ConditionUnknown,
+				Reason:  v1alpha1.ReasonValidationErrored,
+				Message: s.Err.Error(),
+			})
+		} else {
+			validator.SetValidationStatus(&doc.Status, s.MetaBundle, s.Results, 0)
+		}
+
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("encoding status of suite %q: %w", s.Name, err)
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return fmt.Errorf("writing document separator: %w", err)
+			}
+		}
+
+		if _, err := out.Write(data); err != nil {
+			return fmt.Errorf("writing status of suite %q: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+type statusDocument struct {
+	Name    string                    `json:"name"`
+	Sources []string                  `json:"sources,omitempty"`
+	Status  v1alpha1.ValidationStatus `json:"status"`
+}
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// TableWriter renders a Report as human readable tables. One
+// table is rendered for every suite in the report.
+type TableWriter struct {
+	// Timings appends the duration of each validator
+	// run to the rendered rows.
+	Timings bool
+}
+
+func (w TableWriter) Write(out io.Writer, r Report) error {
+	headers := cli.WithHeaders{"STATUS", "SEVERITY", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"}
+
+	if w.Timings {
+		headers = append(headers, "DURATION")
+	}
+
+	for _, s := range r.Suites {
+		if s.Err != nil {
+			fmt.Fprintf(out, "%s\nError: %v\n\n", s.Name, s.Err)
+
+			continue
+		}
+
+		table, err := cli.NewTable(headers)
+		if err != nil {
+			return fmt.Errorf("initializing table: %w", err)
+		}
+
+		for _, res := range s.Results {
+			w.writeResult(table, res)
+		}
+
+		if len(r.Suites) > 1 {
+			fmt.Fprintln(out, s.Name)
+		}
+
+		fmt.Fprintln(out, table.String())
+		fmt.Fprintln(out)
+
+		for _, sup := range s.ExpiredSuppressions {
+			fmt.Fprintf(out, "Warning: %s\n", expiredSuppressionMessage(sup))
+		}
+
+		if len(s.ExpiredSuppressions) > 0 {
+			fmt.Fprintln(out)
+		}
+	}
+
+	fmt.Fprintf(out, "Please consult corresponding validator wikis: %s/<code>.\n", wikiURL)
+
+	return nil
+}
+
+func (w TableWriter) writeResult(t *cli.Table, res validator.Result) {
+	var msgs []string
+
+	if res.IsSuccess() {
+		msgs = []string{"None"}
+	} else if res.IsSkipped() {
+		msgs = []string{res.SkipReason}
+	} else if res.IsError() {
+		msgs = []string{res.Error.Error()}
+	} else {
+		msgs = append(msgs, res.FailureMsgs...)
+
+		for _, msg := range res.SuppressedMsgs {
+			msgs = append(msgs, suppressedMessage(msg))
+		}
+	}
+
+	for _, msg := range msgs {
+		row := append(resultToRow(res), cli.Field{Value: msg})
+
+		if w.Timings {
+			row = append(row, cli.Field{Value: formatDuration(res.Duration)})
+		}
+
+		t.WriteRow(row)
+	}
+}
+
+// formatDuration rounds durations to a
+// precision suitable for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+
+	return d.Round(time.Millisecond).String()
+}
+
+func resultToRow(res validator.Result) cli.TableRow {
+	var status cli.Field
+
+	if res.IsSuccess() {
+		status = cli.Field{
+			Value: "Success",
+			Color: cli.FieldColorGreen,
+		}
+	} else if res.IsSkipped() {
+		status = cli.Field{
+			Value: "Skipped",
+		}
+	} else if res.IsError() {
+		status = cli.Field{
+			Value: "Error",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
+	} else if res.IsSuppressed() {
+		status = cli.Field{
+			Value: "Suppressed",
+			Color: cli.FieldColorYellow,
+		}
+	} else {
+		status = cli.Field{
+			Value: "Failed",
+			Color: cli.FieldColorRed,
+		}
+	}
+
+	return cli.TableRow{
+		status,
+		severityField(res.Severity),
+		cli.Field{Value: res.Code.String()},
+		cli.Field{Value: res.Name},
+		cli.Field{Value: res.Description},
+	}
+}
+
+func severityField(sev validator.Severity) cli.Field {
+	var color cli.FieldColor
+
+	switch sev {
+	case validator.SeverityError:
+		color = cli.FieldColorRed
+	case validator.SeverityWarning:
+		color = cli.FieldColorYellow
+	}
+
+	return cli.Field{
+		Value: sev.String(),
+		Color: color,
+	}
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/controllers"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AddonImageSetWebhook validates AddonImageSet objects on create and
+// update combined with the AddonMetadata of their addon.
+type AddonImageSetWebhook struct {
+	Client client.Reader
+	Runner *validator.Runner
+}
+
+// +kubebuilder:webhook:path=/validate-addonsflow-redhat-openshift-io-v1alpha1-addonimageset,mutating=false,failurePolicy=fail,sideEffects=None,groups=addonsflow.redhat.openshift.io,resources=addonimagesets,verbs=create;update,versions=v1alpha1,name=vaddonimageset.addonsflow.redhat.openshift.io,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the webhook with the given manager.
+func (w *AddonImageSetWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.AddonImageSet{}).
+		WithValidator(w).
+		Complete()
+}
+
+func (w *AddonImageSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, obj)
+}
+
+func (w *AddonImageSetWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, newObj)
+}
+
+func (w *AddonImageSetWebhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *AddonImageSetWebhook) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	is, ok := obj.(*v1alpha1.AddonImageSet)
+	if !ok {
+		return nil, fmt.Errorf("expected an AddonImageSet but got %T", obj)
+	}
+
+	if _, err := is.Spec.GetSemver(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+
+	addonID := is.Spec.GetAddonID()
+
+	addons, err := controllers.FindAddonMetadataForImageSet(ctx, w.Client, is)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addons) == 0 {
+		return admission.Warnings{
+			fmt.Sprintf("AddonMetadata for addon %q not found; imageset was not validated.", addonID),
+		}, nil
+	}
+
+	var (
+		warnings admission.Warnings
+		errs     []error
+	)
+
+	// the imageset is validated against every AddonMetadata
+	// referencing it as their specs may differ
+	for _, addon := range addons {
+		addonWarnings, err := validateImageSet(ctx, w.Runner, &addon.Spec, &is.Spec)
+
+		for _, warning := range addonWarnings {
+			warnings = append(warnings, fmt.Sprintf("AddonMetadata %q: %s", addon.Name, warning))
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("AddonMetadata %q: %w", addon.Name, err))
+		}
+	}
+
+	return warnings, errors.Join(errs...)
+}
+
+func validateImageSet(ctx context.Context, runner *validator.Runner, meta *v1alpha1.AddonMetadataSpec, is *v1alpha1.AddonImageSetSpec) (admission.Warnings, error) {
+	combined, err := meta.CombineWithImageSet(is)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+
+	return validateMeta(ctx, runner, combined)
+}
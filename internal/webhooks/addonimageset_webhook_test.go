@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAddonImageSetWebhookValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ImageSet         *v1alpha1.AddonImageSet
+		ImageSets        []client.Object
+		Addons           []client.Object
+		ExpectedError    bool
+		ExpectedWarnings int
+	}{
+		"valid": {
+			ImageSet: newAddonImageSet("reference-addon.v1.0.0"),
+			Addons: []client.Object{
+				newAddonMetadata(v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					ImageSetVersion: stringPtr("latest"),
+				}),
+			},
+		},
+		"failure": {
+			ImageSet: newAddonImageSet("reference-addon.v1.0.0"),
+			Addons: []client.Object{
+				newAddonMetadata(v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					OcmQuotaName:    "invalid",
+					ImageSetVersion: stringPtr("latest"),
+				}),
+			},
+			ExpectedError: true,
+		},
+		"unreferencing metadata ignored": {
+			ImageSet: newAddonImageSet("reference-addon.v1.0.0"),
+			ImageSets: []client.Object{
+				newAddonImageSet("reference-addon.v2.0.0"),
+			},
+			Addons: []client.Object{
+				newNamedAddonMetadata("reference-addon-b", v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					ImageSetVersion: stringPtr("1.0.0"),
+				}),
+				newNamedAddonMetadata("reference-addon-a", v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					OcmQuotaName:    "invalid",
+					ImageSetVersion: stringPtr("latest"),
+				}),
+			},
+		},
+		"every referencing metadata validated": {
+			ImageSet: newAddonImageSet("reference-addon.v1.0.0"),
+			Addons: []client.Object{
+				newNamedAddonMetadata("reference-addon-a", v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					ImageSetVersion: stringPtr("1.0.0"),
+				}),
+				newNamedAddonMetadata("reference-addon-b", v1alpha1.AddonMetadataSpec{
+					ID:              "reference-addon",
+					Description:     "An addon.",
+					OcmQuotaName:    "invalid",
+					ImageSetVersion: stringPtr("latest"),
+				}),
+			},
+			ExpectedError: true,
+		},
+		"invalid version": {
+			ImageSet:      newAddonImageSet("reference-addon"),
+			ExpectedError: true,
+		},
+		"metadata not found": {
+			ImageSet:         newAddonImageSet("reference-addon.v1.0.0"),
+			ExpectedWarnings: 1,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &AddonImageSetWebhook{
+				Client: newFakeClient(t, append(tc.ImageSets, tc.Addons...)...),
+				Runner: newTestRunner(t),
+			}
+
+			warnings, err := w.ValidateCreate(context.Background(), tc.ImageSet)
+			if tc.ExpectedError {
+				require.ErrorIs(t, err, ErrValidationFailed)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Len(t, warnings, tc.ExpectedWarnings)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/controllers"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	defaultInstallMode    = "OwnNamespace"
+	defaultDefaultChannel = "alpha"
+)
+
+// AddonMetadataWebhook defaults AddonMetadata objects and validates
+// them on create and update. Metadata referencing an AddonImageSet is
+// validated combined with that imageset if it already exists.
+type AddonMetadataWebhook struct {
+	Client client.Reader
+	Runner *validator.Runner
+}
+
+// +kubebuilder:webhook:path=/mutate-addonsflow-redhat-openshift-io-v1alpha1-addonmetadata,mutating=true,failurePolicy=fail,sideEffects=None,groups=addonsflow.redhat.openshift.io,resources=addonmetadata,verbs=create;update,versions=v1alpha1,name=maddonmetadata.addonsflow.redhat.openshift.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-addonsflow-redhat-openshift-io-v1alpha1-addonmetadata,mutating=false,failurePolicy=fail,sideEffects=None,groups=addonsflow.redhat.openshift.io,resources=addonmetadata,verbs=create;update,versions=v1alpha1,name=vaddonmetadata.addonsflow.redhat.openshift.io,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the webhook with the given manager.
+func (w *AddonMetadataWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.AddonMetadata{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default sets the installMode and defaultChannel of metadata
+// which leaves them unset. Other fields are left to the
+// validators so that mistakes in them are still reported.
+func (w *AddonMetadataWebhook) Default(_ context.Context, obj runtime.Object) error {
+	addon, ok := obj.(*v1alpha1.AddonMetadata)
+	if !ok {
+		return fmt.Errorf("expected an AddonMetadata but got %T", obj)
+	}
+
+	defaultAddonMetadataSpec(&addon.Spec)
+
+	return nil
+}
+
+func defaultAddonMetadataSpec(spec *v1alpha1.AddonMetadataSpec) {
+	if spec.InstallMode == "" {
+		spec.InstallMode = defaultInstallMode
+	}
+
+	if spec.DefaultChannel == "" {
+		spec.DefaultChannel = defaultDefaultChannel
+
+		// legacy addons list their channels explicitly
+		if spec.Channels != nil && len(*spec.Channels) == 1 {
+			spec.DefaultChannel = (*spec.Channels)[0].Name
+		}
+	}
+}
+
+func (w *AddonMetadataWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, obj)
+}
+
+func (w *AddonMetadataWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return w.validate(ctx, newObj)
+}
+
+func (w *AddonMetadataWebhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *AddonMetadataWebhook) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	addon, ok := obj.(*v1alpha1.AddonMetadata)
+	if !ok {
+		return nil, fmt.Errorf("expected an AddonMetadata but got %T", obj)
+	}
+
+	spec := &addon.Spec
+
+	if err := spec.VerifyIndexImageSource(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+
+	if spec.ImageSetVersion == nil {
+		return validateMeta(ctx, w.Runner, spec)
+	}
+
+	is, found, err := controllers.FindImageSet(ctx, w.Client, addon.Namespace, spec.ID, *spec.ImageSetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		warnings, err := validateMeta(ctx, w.Runner, spec)
+
+		return append(warnings, fmt.Sprintf(
+			"AddonImageSet for addon %q with version %q not found; validated without imageset.",
+			spec.ID, *spec.ImageSetVersion,
+		)), err
+	}
+
+	combined, err := spec.CombineWithImageSet(&is.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+
+	return validateMeta(ctx, w.Runner, combined)
+}
@@ -0,0 +1,168 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAddonMetadataWebhookDefault(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Spec     v1alpha1.AddonMetadataSpec
+		Expected v1alpha1.AddonMetadataSpec
+	}{
+		"empty": {
+			Spec: v1alpha1.AddonMetadataSpec{},
+			Expected: v1alpha1.AddonMetadataSpec{
+				InstallMode:    "OwnNamespace",
+				DefaultChannel: "alpha",
+			},
+		},
+		"default channel from legacy channels": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:              "reference-addon",
+				TargetNamespace: "reference-addon",
+				Channels:        &[]v1alpha1.Channel{{Name: "stable"}},
+			},
+			Expected: v1alpha1.AddonMetadataSpec{
+				ID:              "reference-addon",
+				TargetNamespace: "reference-addon",
+				InstallMode:     "OwnNamespace",
+				DefaultChannel:  "stable",
+				Channels:        &[]v1alpha1.Channel{{Name: "stable"}},
+			},
+		},
+		"set fields are kept": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:                   "reference-addon",
+				Label:                "api.openshift.com/addon-other",
+				TargetNamespace:      "reference-addon",
+				Namespaces:           []string{"reference-addon", "reference-addon-extra"},
+				InstallMode:          "AllNamespaces",
+				DefaultChannel:       "beta",
+				NamespaceLabels:      map[string]string{"a": "b"},
+				NamespaceAnnotations: map[string]string{"c": "d"},
+			},
+			Expected: v1alpha1.AddonMetadataSpec{
+				ID:                   "reference-addon",
+				Label:                "api.openshift.com/addon-other",
+				TargetNamespace:      "reference-addon",
+				Namespaces:           []string{"reference-addon", "reference-addon-extra"},
+				InstallMode:          "AllNamespaces",
+				DefaultChannel:       "beta",
+				NamespaceLabels:      map[string]string{"a": "b"},
+				NamespaceAnnotations: map[string]string{"c": "d"},
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newAddonMetadata(tc.Spec)
+
+			require.NoError(t, (&AddonMetadataWebhook{}).Default(context.Background(), addon))
+
+			assert.Equal(t, tc.Expected, addon.Spec)
+		})
+	}
+}
+
+func TestAddonMetadataWebhookValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Spec             v1alpha1.AddonMetadataSpec
+		ImageSets        []client.Object
+		ExpectedError    bool
+		ExpectedWarnings int
+	}{
+		"valid": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:          "reference-addon",
+				Description: "An addon.",
+				IndexImage:  stringPtr("quay.io/osd-addons/reference-addon-index"),
+			},
+		},
+		"warning": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:         "reference-addon",
+				IndexImage: stringPtr("quay.io/osd-addons/reference-addon-index"),
+			},
+			ExpectedWarnings: 1,
+		},
+		"failure": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:           "reference-addon",
+				Description:  "An addon.",
+				OcmQuotaName: "invalid",
+				IndexImage:   stringPtr("quay.io/osd-addons/reference-addon-index"),
+			},
+			ExpectedError: true,
+		},
+		"conflicting index image source": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:              "reference-addon",
+				Description:     "An addon.",
+				IndexImage:      stringPtr("quay.io/osd-addons/reference-addon-index"),
+				ImageSetVersion: stringPtr("latest"),
+			},
+			ExpectedError: true,
+		},
+		"imageset found": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:              "reference-addon",
+				Description:     "An addon.",
+				ImageSetVersion: stringPtr("1.0.0"),
+			},
+			ImageSets: []client.Object{newAddonImageSet("reference-addon.v1.0.0")},
+		},
+		"imageset not found": {
+			Spec: v1alpha1.AddonMetadataSpec{
+				ID:              "reference-addon",
+				Description:     "An addon.",
+				ImageSetVersion: stringPtr("2.0.0"),
+			},
+			ImageSets:        []client.Object{newAddonImageSet("reference-addon.v1.0.0")},
+			ExpectedWarnings: 1,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &AddonMetadataWebhook{
+				Client: newFakeClient(t, tc.ImageSets...),
+				Runner: newTestRunner(t),
+			}
+
+			addon := newAddonMetadata(tc.Spec)
+
+			for _, validate := range map[string]func() ([]string, error){
+				"create": func() ([]string, error) {
+					return w.ValidateCreate(context.Background(), addon)
+				},
+				"update": func() ([]string, error) {
+					return w.ValidateUpdate(context.Background(), addon.DeepCopy(), addon)
+				},
+			} {
+				warnings, err := validate()
+				if tc.ExpectedError {
+					require.ErrorIs(t, err, ErrValidationFailed)
+				} else {
+					require.NoError(t, err)
+				}
+
+				assert.Len(t, warnings, tc.ExpectedWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var ErrValidationFailed = errors.New("addon metadata failed validation")
+
+// OfflineFilter matches the validators which can run on addon
+// metadata alone. Validators requiring network access or the
+// extracted bundles of the addon are excluded from admission as
+// requests must be answered quickly and without calls to external
+// services.
+func OfflineFilter() validator.Filter {
+	caps := append(validator.NetworkCapabilities(), validator.CapabilityBundles)
+
+	return validator.Not(validator.RequiresAnyCapability(caps...))
+}
+
+// validateMeta runs the offline validators of the given runner against
+// the given metadata. Failures at error severity deny the request while
+// lower severity failures and validator errors are returned as warnings.
+func validateMeta(ctx context.Context, runner *validator.Runner, meta *v1alpha1.AddonMetadataSpec) (admission.Warnings, error) {
+	mb := types.MetaBundle{
+		AddonMeta: meta,
+	}
+
+	var results validator.ResultList
+
+	for res := range runner.Run(ctx, mb, OfflineFilter()) {
+		results = append(results, res)
+	}
+
+	sort.Stable(results)
+
+	var (
+		warnings admission.Warnings
+		denials  []string
+	)
+
+	for _, res := range results {
+		switch {
+		case res.IsSuccess(), res.IsSkipped():
+			continue
+		case res.IsError():
+			warnings = append(warnings, fmt.Sprintf("%s %s: %v", res.Code, res.Name, res.Error))
+		case res.Severity >= validator.SeverityError:
+			for _, msg := range res.FailureMsgs {
+				denials = append(denials, fmt.Sprintf("%s %s: %s", res.Code, res.Name, msg))
+			}
+		default:
+			for _, msg := range res.FailureMsgs {
+				warnings = append(warnings, fmt.Sprintf("%s %s (%s): %s", res.Code, res.Name, res.Severity, msg))
+			}
+		}
+	}
+
+	if len(denials) > 0 {
+		return warnings, fmt.Errorf("%w:\n%s", ErrValidationFailed, strings.Join(denials, "\n"))
+	}
+
+	return warnings, nil
+}
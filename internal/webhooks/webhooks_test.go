@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "addons"
+
+// stubValidator fails with its severity whenever check
+// returns a non-empty message.
+type stubValidator struct {
+	*validator.Base
+	check func(*v1alpha1.AddonMetadataSpec) string
+}
+
+func (v *stubValidator) Run(_ context.Context, mb types.MetaBundle) validator.Result {
+	if msg := v.check(mb.AddonMeta); msg != "" {
+		return v.Fail(msg)
+	}
+
+	return v.Success()
+}
+
+func newStub(code validator.Code, sev validator.Severity, check func(*v1alpha1.AddonMetadataSpec) string, caps ...validator.Capability) validator.Initializer {
+	return func(validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(code,
+			validator.BaseName("stub"),
+			validator.BaseSeverity(sev),
+			validator.BaseCapabilities(caps...),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &stubValidator{Base: base, check: check}, nil
+	}
+}
+
+func newTestRunner(t *testing.T) *validator.Runner {
+	t.Helper()
+
+	runner, err := validator.NewRunner(
+		validator.WithInitializers{
+			newStub(2, validator.SeverityError, func(meta *v1alpha1.AddonMetadataSpec) string {
+				if meta.OcmQuotaName == "invalid" {
+					return "invalid quota name"
+				}
+
+				return ""
+			}),
+			newStub(4, validator.SeverityWarning, func(meta *v1alpha1.AddonMetadataSpec) string {
+				if meta.Description == "" {
+					return "missing description"
+				}
+
+				return ""
+			}),
+			// requires the registry and must never run during admission
+			newStub(5, validator.SeverityError, func(*v1alpha1.AddonMetadataSpec) string {
+				return "network validator ran"
+			}, validator.CapabilityNetwork, validator.CapabilityRegistry),
+		},
+	)
+	require.NoError(t, err)
+
+	return runner
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		Build()
+}
+
+func newAddonMetadata(spec v1alpha1.AddonMetadataSpec) *v1alpha1.AddonMetadata {
+	return &v1alpha1.AddonMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: spec.ID},
+		Spec:       spec,
+	}
+}
+
+func newNamedAddonMetadata(name string, spec v1alpha1.AddonMetadataSpec) *v1alpha1.AddonMetadata {
+	addon := newAddonMetadata(spec)
+	addon.Name = name
+
+	return addon
+}
+
+func newAddonImageSet(name string) *v1alpha1.AddonImageSet {
+	return &v1alpha1.AddonImageSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Spec: v1alpha1.AddonImageSetSpec{
+			Name:       name,
+			IndexImage: "quay.io/osd-addons/reference-addon-index",
+		},
+	}
+}
+
+func stringPtr(s string) *string { return &s }
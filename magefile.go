@@ -79,6 +79,7 @@ func (All) Clean(ctx context.Context) {
 	mg.CtxDeps(
 		ctx,
 		Build.CleanCLI,
+		Build.CleanOperator,
 		Test.Clean,
 	)
 }
@@ -294,6 +295,37 @@ func (Build) CleanCLI() error {
 	return sh.Rm(filepath.Join(_projectRoot, "bin", "mtcli"))
 }
 
+func (Build) Operator(ctx context.Context) error {
+	build := gocmd(
+		command.WithCurrentEnv(true),
+		command.WithEnv{
+			"CGO_ENABLED": "1",
+			"CGO_CFLAGS":  "-DSQLITE_ENABLE_JSON1",
+		},
+		command.WithArgs{
+			"build", "-a",
+			"-o", filepath.Join(_projectRoot, "bin", "addon-metadata-operator"),
+			filepath.Join("cmd", "addon-metadata-operator", "main.go"),
+		},
+		command.WithConsoleOut(mg.Verbose()),
+		command.WithContext{Context: ctx},
+	)
+
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("starting to build addon-metadata-operator: %w", err)
+	}
+
+	if build.Success() {
+		return nil
+	}
+
+	return fmt.Errorf("building addon-metadata-operator: %w", build.Error())
+}
+
+func (Build) CleanOperator() error {
+	return sh.Rm(filepath.Join(_projectRoot, "bin", "addon-metadata-operator"))
+}
+
 var gocmd = command.NewCommandAlias(mg.GoCmd())
 
 type Generate mg.Namespace
@@ -325,6 +357,39 @@ func (Generate) Boilerplate(ctx context.Context) error {
 	return fmt.Errorf("generating boilerplate: %w", generate.Error())
 }
 
+// Generates CRD, RBAC and webhook manifests for the operator.
+func (Generate) Manifests(ctx context.Context) error {
+	mg.CtxDeps(ctx,
+		Deps.UpdateControllerGen,
+	)
+
+	generate := controllergen(
+		command.WithArgs{
+			"crd",
+			`rbac:roleName="manager-role"`,
+			"webhook",
+			`paths="./api/..."`,
+			`paths="./internal/controllers/..."`,
+			`paths="./internal/webhooks/..."`,
+			`output:crd:artifacts:config="config/crd/bases"`,
+			`output:rbac:artifacts:config="config/rbac"`,
+			`output:webhook:artifacts:config="config/webhook"`,
+		},
+		command.WithConsoleOut(mg.Verbose()),
+		command.WithContext{Context: ctx},
+	)
+
+	if err := generate.Run(); err != nil {
+		return fmt.Errorf("starting to generate manifests: %w", err)
+	}
+
+	if generate.Success() {
+		return nil
+	}
+
+	return fmt.Errorf("generating manifests: %w", generate.Error())
+}
+
 var controllergen = command.NewCommandAlias(filepath.Join(_depBin, "controller-gen"))
 
 func (Generate) Clean(ctx context.Context) error {
@@ -0,0 +1,195 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CatalogExtractor - reads a local file-based catalog (FBC) directory
+// instead of resolving an indexImage from a registry. It implements
+// IndexExtractor by listing the bundle images of the catalog and
+// BundleExtractor by building bundles from their 'olm.bundle.object'
+// properties, so that no registry access is required when used for
+// both. The indexImage passed to its methods is ignored.
+// FBC format: https://olm.operatorframework.io/docs/reference/file-based-catalogs/
+type CatalogExtractor struct {
+	Log logrus.FieldLogger
+	Dir string
+
+	once   sync.Once
+	cfg    *declcfg.DeclarativeConfig
+	cfgErr error
+}
+
+// NewCatalogExtractor - returns a CatalogExtractor reading the catalog
+// in the given directory. The catalog is loaded on first use.
+func NewCatalogExtractor(dir string, opts ...CatalogExtractorOpt) *CatalogExtractor {
+	extractor := CatalogExtractor{
+		Dir: dir,
+	}
+
+	for _, opt := range opts {
+		opt(&extractor)
+	}
+
+	if extractor.Log == nil {
+		extractor.Log = logrus.New()
+	}
+
+	extractor.Log = extractor.Log.WithField("source", "catalogExtractor")
+
+	return &extractor
+}
+
+type CatalogExtractorOpt func(e *CatalogExtractor)
+
+func WithCatalogLog(log logrus.FieldLogger) CatalogExtractorOpt {
+	return func(e *CatalogExtractor) {
+		e.Log = log
+	}
+}
+
+// ExtractBundleImages - returns a sorted list of the bundle images of pkgName.
+func (e *CatalogExtractor) ExtractBundleImages(ctx context.Context, _ string, pkgName string) ([]string, error) {
+	return e.extractBundleImages(ctx, pkgName)
+}
+
+// ExtractAllBundleImages - returns a sorted list of the bundle images of all packages.
+func (e *CatalogExtractor) ExtractAllBundleImages(ctx context.Context, _ string) ([]string, error) {
+	return e.extractBundleImages(ctx, "")
+}
+
+func (e *CatalogExtractor) extractBundleImages(ctx context.Context, pkgName string) ([]string, error) {
+	cfg, err := e.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+
+	for _, b := range cfg.Bundles {
+		if pkgName != "" && b.Package != pkgName {
+			continue
+		}
+
+		images = append(images, b.Image)
+	}
+
+	return sortedBundleImages(images), nil
+}
+
+var ErrMissingBundleObjects = errors.New("catalog bundle has no 'olm.bundle.object' properties")
+
+// Extract - builds the bundle with the given image from its
+// 'olm.bundle.object' properties in the catalog.
+func (e *CatalogExtractor) Extract(ctx context.Context, bundleImage string) (operator.Bundle, error) {
+	cfg, err := e.load(ctx)
+	if err != nil {
+		return operator.Bundle{}, err
+	}
+
+	for _, b := range cfg.Bundles {
+		if b.Image != bundleImage {
+			continue
+		}
+
+		if len(b.Objects) == 0 {
+			return operator.Bundle{}, fmt.Errorf("bundle %q: %w", b.Name, ErrMissingBundleObjects)
+		}
+
+		return newBundleFromCatalog(cfg, b)
+	}
+
+	return operator.Bundle{}, fmt.Errorf("bundle image %q not found in catalog %q", bundleImage, e.Dir)
+}
+
+func (e *CatalogExtractor) load(ctx context.Context) (*declcfg.DeclarativeConfig, error) {
+	e.once.Do(func() {
+		e.Log.Debugf("loading catalog from '%s'", e.Dir)
+
+		e.cfg, e.cfgErr = declcfg.LoadFS(ctx, os.DirFS(e.Dir))
+		if e.cfgErr != nil {
+			e.cfgErr = fmt.Errorf("loading catalog from %q: %w", e.Dir, e.cfgErr)
+		}
+	})
+
+	return e.cfg, e.cfgErr
+}
+
+func newBundleFromCatalog(cfg *declcfg.DeclarativeConfig, b declcfg.Bundle) (operator.Bundle, error) {
+	objs := make([]*unstructured.Unstructured, 0, len(b.Objects))
+
+	for _, raw := range b.Objects {
+		var obj unstructured.Unstructured
+
+		if err := obj.UnmarshalJSON([]byte(raw)); err != nil {
+			return operator.Bundle{}, fmt.Errorf("decoding object of bundle %q: %w", b.Name, err)
+		}
+
+		objs = append(objs, &obj)
+	}
+
+	channels := catalogChannels(cfg, b)
+
+	annotations := registry.Annotations{
+		PackageName:        b.Package,
+		Channels:           strings.Join(channels, ","),
+		DefaultChannelName: catalogDefaultChannel(cfg, b.Package),
+	}
+
+	regBundle := registry.NewBundle(b.Package, &annotations, objs...)
+	regBundle.BundleImage = b.Image
+	regBundle.Channels = channels
+
+	bundle, err := operator.NewBundleFromRegistryBundle(*regBundle)
+	if err != nil {
+		return operator.Bundle{}, fmt.Errorf("generating bundle %q: %w", b.Name, err)
+	}
+
+	return bundle, nil
+}
+
+// catalogChannels returns the sorted names of all channels of the
+// bundle's package which contain the bundle.
+func catalogChannels(cfg *declcfg.DeclarativeConfig, b declcfg.Bundle) []string {
+	var channels []string
+
+	for _, ch := range cfg.Channels {
+		if ch.Package != b.Package {
+			continue
+		}
+
+		for _, entry := range ch.Entries {
+			if entry.Name == b.Name {
+				channels = append(channels, ch.Name)
+
+				break
+			}
+		}
+	}
+
+	sort.Strings(channels)
+
+	return channels
+}
+
+func catalogDefaultChannel(cfg *declcfg.DeclarativeConfig, pkgName string) string {
+	for _, pkg := range cfg.Packages {
+		if pkg.Name == pkgName {
+			return pkg.DefaultChannel
+		}
+	}
+
+	return ""
+}
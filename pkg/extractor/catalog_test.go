@@ -0,0 +1,88 @@
+package extractor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const catalogBundleImage = "quay.io/osd-addons/reference-addon-bundle@sha256:8c4d1e8a6e8f1d6b8f3c8fc5f7e0d1c2b3a4958677a8b9c0d1e2f3a4b5c6d7e8"
+
+func TestCatalogExtractorImplements(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(IndexExtractor), &CatalogExtractor{})
+	require.Implements(t, new(BundleExtractor), &CatalogExtractor{})
+}
+
+func TestCatalogExtractorExtractBundleImages(t *testing.T) {
+	t.Parallel()
+
+	extractor := NewCatalogExtractor(referenceAddonCatalog())
+
+	for name, tc := range map[string]struct {
+		PkgName        string
+		ExpectedImages []string
+	}{
+		"reference-addon": {
+			PkgName:        "reference-addon",
+			ExpectedImages: []string{catalogBundleImage},
+		},
+		"unknown package": {
+			PkgName: "unknown",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			images, err := extractor.ExtractBundleImages(context.Background(), "ignored", tc.PkgName)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, tc.ExpectedImages, images)
+		})
+	}
+}
+
+func TestCatalogExtractorExtract(t *testing.T) {
+	t.Parallel()
+
+	extractor := NewCatalogExtractor(referenceAddonCatalog())
+
+	bundle, err := extractor.Extract(context.Background(), catalogBundleImage)
+	require.NoError(t, err)
+
+	testCase{
+		BundleImage:         catalogBundleImage,
+		ExpectedPackageName: "reference-addon",
+		ExpectedCSVName:     "reference-addon.v0.1.6",
+		ExpectedCSVVersion:  "0.1.6",
+	}.AssertExpectations(t, bundle)
+
+	assert.Equal(t, "alpha", bundle.Annotations.DefaultChannelName)
+	assert.Equal(t, []string{"alpha"}, bundle.Channels)
+
+	_, err = extractor.Extract(context.Background(), "quay.io/osd-addons/unknown:latest")
+	require.Error(t, err)
+}
+
+func TestCatalogExtractorMainExtractor(t *testing.T) {
+	t.Parallel()
+
+	catalog := NewCatalogExtractor(referenceAddonCatalog())
+	extractor := New(WithIndexExtractor(catalog), WithBundleExtractor(catalog))
+
+	bundles, err := extractor.ExtractBundles(context.Background(), "quay.io/osd-addons/ignored:latest", "reference-addon")
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	assert.Equal(t, "0.1.6", bundles[0].Version)
+}
+
+func referenceAddonCatalog() string {
+	return filepath.Join("..", "..", "internal", "testdata", "catalogs", "reference-addon")
+}
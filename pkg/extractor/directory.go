@@ -0,0 +1,139 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	opmbundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
+	"github.com/sirupsen/logrus"
+)
+
+// DirectoryExtractor - reads unpacked bundles from a local directory
+// instead of resolving an indexImage and pulling bundle images. Every
+// directory below the root containing both a 'manifests' and a
+// 'metadata' directory is read as a bundle. The indexImage passed to
+// its methods is ignored.
+type DirectoryExtractor struct {
+	Log logrus.FieldLogger
+	Dir string
+}
+
+// NewDirectoryExtractor - returns a DirectoryExtractor reading bundles
+// below the given directory.
+func NewDirectoryExtractor(dir string, opts ...DirectoryExtractorOpt) *DirectoryExtractor {
+	extractor := DirectoryExtractor{
+		Dir: dir,
+	}
+
+	for _, opt := range opts {
+		opt(&extractor)
+	}
+
+	if extractor.Log == nil {
+		extractor.Log = logrus.New()
+	}
+
+	extractor.Log = extractor.Log.WithField("source", "directoryExtractor")
+
+	return &extractor
+}
+
+type DirectoryExtractorOpt func(e *DirectoryExtractor)
+
+func WithDirectoryLog(log logrus.FieldLogger) DirectoryExtractorOpt {
+	return func(e *DirectoryExtractor) {
+		e.Log = log
+	}
+}
+
+// ExtractBundles - reads all bundles below the directory matching pkgName.
+func (e *DirectoryExtractor) ExtractBundles(ctx context.Context, _ string, pkgName string) ([]operator.Bundle, error) {
+	if pkgName == "" {
+		return nil, errors.New("invalid empty pkgName")
+	}
+
+	all, err := e.ExtractAllBundles(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var res []operator.Bundle
+
+	for _, b := range all {
+		if b.Annotations.PackageName != pkgName {
+			continue
+		}
+
+		res = append(res, b)
+	}
+
+	return res, nil
+}
+
+// ExtractAllBundles - reads all bundles below the directory.
+func (e *DirectoryExtractor) ExtractAllBundles(ctx context.Context, _ string) ([]operator.Bundle, error) {
+	dirs, err := e.bundleDirs()
+	if err != nil {
+		return nil, fmt.Errorf("finding bundles in %q: %w", e.Dir, err)
+	}
+
+	res := make([]operator.Bundle, 0, len(dirs))
+
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e.Log.Debugf("reading bundle from '%s'", dir)
+
+		bundle, err := operator.NewBundleFromDirectory(dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle from %q: %w", dir, err)
+		}
+
+		res = append(res, bundle)
+	}
+
+	return res, nil
+}
+
+func (e *DirectoryExtractor) bundleDirs() ([]string, error) {
+	var dirs []string
+
+	err := filepath.WalkDir(e.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if !isDir(filepath.Join(path, opmbundle.ManifestsDir)) || !isDir(filepath.Join(path, opmbundle.MetadataDir)) {
+			return nil
+		}
+
+		dirs = append(dirs, path)
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(dirs)
+
+	return dirs, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
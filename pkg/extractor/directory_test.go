@@ -0,0 +1,62 @@
+package extractor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryExtractorImplements(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Extractor), &DirectoryExtractor{})
+}
+
+func TestDirectoryExtractorExtractBundles(t *testing.T) {
+	t.Parallel()
+
+	extractor := NewDirectoryExtractor(filepath.Join("..", "..", "internal", "testdata", "bundles"))
+
+	for name, tc := range map[string]struct {
+		PkgName          string
+		ExpectedVersions []string
+	}{
+		"reference-addon": {
+			PkgName:          "reference-addon",
+			ExpectedVersions: []string{"0.1.6"},
+		},
+		"unknown package": {
+			PkgName: "unknown",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bundles, err := extractor.ExtractBundles(context.Background(), "ignored", tc.PkgName)
+			require.NoError(t, err)
+
+			versions := make([]string, 0, len(bundles))
+			for _, b := range bundles {
+				assert.Equal(t, tc.PkgName, b.Annotations.PackageName)
+
+				versions = append(versions, b.Version)
+			}
+
+			assert.ElementsMatch(t, tc.ExpectedVersions, versions)
+		})
+	}
+}
+
+func TestDirectoryExtractorMissingDir(t *testing.T) {
+	t.Parallel()
+
+	extractor := NewDirectoryExtractor("does-not-exist")
+
+	_, err := extractor.ExtractAllBundles(context.Background(), "")
+	require.Error(t, err)
+}
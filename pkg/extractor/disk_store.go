@@ -0,0 +1,435 @@
+package extractor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// Codec converts the data held by a Store to and from bytes.
+type Codec interface {
+	Encode(data interface{}) ([]byte, error)
+	Decode(raw []byte) (interface{}, error)
+}
+
+// BundleCodec encodes the operator.Bundle values stored by
+// BundleCacheImpl as JSON.
+type BundleCodec struct{}
+
+func (c BundleCodec) Encode(data interface{}) ([]byte, error) {
+	bundle, ok := data.(operator.Bundle)
+	if !ok {
+		return nil, ErrInvalidBundleData
+	}
+
+	return json.Marshal(bundle)
+}
+
+func (c BundleCodec) Decode(raw []byte) (interface{}, error) {
+	var bundle operator.Bundle
+
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return nil, fmt.Errorf("unmarshalling bundle: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// IndexCodec encodes the package to bundle image mappings stored
+// by IndexCacheImpl as JSON.
+type IndexCodec struct{}
+
+func (c IndexCodec) Encode(data interface{}) ([]byte, error) {
+	pkgs, ok := data.(map[string][]string)
+	if !ok {
+		return nil, ErrInvalidIndexData
+	}
+
+	return json.Marshal(pkgs)
+}
+
+func (c IndexCodec) Decode(raw []byte) (interface{}, error) {
+	var pkgs map[string][]string
+
+	if err := json.Unmarshal(raw, &pkgs); err != nil {
+		return nil, fmt.Errorf("unmarshalling index: %w", err)
+	}
+
+	return pkgs, nil
+}
+
+// NewDiskStore returns an implementation of the 'Store' interface
+// which persists data as files below the given directory using the
+// given Codec. The directory is created on the first write. Each
+// entry is stored in a file named after the SHA-256 sum of its id
+// so that ids referencing images by digest are content addressed.
+// A MainExtractor configured with a DigestResolver reads and writes
+// index listings by digest even for index images referenced by tag.
+// A variadic slice of options can be used to limit the age and total
+// size of the stored entries.
+func NewDiskStore(dir string, codec Codec, opts ...DiskStoreOption) *DiskStore {
+	var cfg DiskStoreConfig
+
+	cfg.Option(opts...)
+
+	return &DiskStore{
+		cfg:   cfg,
+		dir:   dir,
+		codec: codec,
+	}
+}
+
+// DiskStore is safe for concurrent use by multiple goroutines and
+// by multiple processes sharing the same directory.
+type DiskStore struct {
+	cfg   DiskStoreConfig
+	dir   string
+	codec Codec
+	lock  sync.Mutex
+}
+
+const diskStoreExt = ".json"
+
+type diskEntry struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      []byte    `json:"data"`
+}
+
+// DiskStoreEntry describes an entry of a DiskStore.
+type DiskStoreEntry struct {
+	// Key is the id the entry was written with.
+	Key string
+	// Size is the size of the entry on disk in bytes.
+	Size int64
+	// CreatedAt is the time the entry was written.
+	CreatedAt time.Time
+	// LastUsed is the time the entry was last read or written.
+	LastUsed time.Time
+	// Expired is 'true' if the entry is no longer returned
+	// by the store.
+	Expired bool
+
+	path string
+}
+
+// Read returns the data stored for the given id. Entries which are
+// expired or cannot be decoded are removed and reported as missing.
+func (s *DiskStore) Read(id interface{}) (interface{}, bool) {
+	key := fmt.Sprint(id)
+	path := s.path(key)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Key != key {
+		_ = os.Remove(path)
+
+		return nil, false
+	}
+
+	if s.expired(entry.Key, entry.CreatedAt) {
+		_ = os.Remove(path)
+
+		return nil, false
+	}
+
+	data, err := s.codec.Decode(entry.Data)
+	if err != nil {
+		_ = os.Remove(path)
+
+		return nil, false
+	}
+
+	// refresh the modification time which orders
+	// entries for eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// Write persists the data for the given id and evicts the least
+// recently used entries if the store exceeds its maximum size.
+func (s *DiskStore) Write(id, data interface{}) error {
+	encoded, err := s.codec.Encode(data)
+	if err != nil {
+		return fmt.Errorf("encoding data: %w", err)
+	}
+
+	key := fmt.Sprint(id)
+
+	raw, err := json.Marshal(diskEntry{
+		Key:       key,
+		CreatedAt: time.Now(),
+		Data:      encoded,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	// write to a temporary file first so that concurrent
+	// readers never observe partially written entries
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("writing entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("moving entry into place: %w", err)
+	}
+
+	if err := s.evict(); err != nil {
+		return fmt.Errorf("evicting entries: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns all entries of the store ordered from the least
+// to the most recently used.
+func (s *DiskStore) Entries() ([]DiskStoreEntry, error) {
+	files, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	var entries []DiskStoreEntry
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != diskStoreExt {
+			continue
+		}
+
+		path := filepath.Join(s.dir, f.Name())
+
+		info, err := f.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("inspecting cache entry: %w", err)
+		}
+
+		raw, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("reading cache entry: %w", err)
+		}
+
+		entry := DiskStoreEntry{
+			Size:     info.Size(),
+			LastUsed: info.ModTime(),
+			path:     path,
+		}
+
+		var de diskEntry
+
+		if err := json.Unmarshal(raw, &de); err != nil {
+			// undecodable entries are reported as expired
+			// so that they are removed by Prune
+			entry.Expired = true
+		} else {
+			entry.Key = de.Key
+			entry.CreatedAt = de.CreatedAt
+			entry.Expired = s.expired(de.Key, de.CreatedAt)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+
+	return entries, nil
+}
+
+// Prune removes all expired entries from the store.
+func (s *DiskStore) Prune() error {
+	entries, err := s.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !e.Expired {
+			continue
+		}
+
+		if err := removeEntry(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Clear removes all entries from the store.
+func (s *DiskStore) Clear() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("removing cache directory: %w", err)
+	}
+
+	return nil
+}
+
+func (s *DiskStore) evict() error {
+	if s.cfg.MaxSize <= 0 {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := s.Entries()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	for _, e := range entries {
+		if total <= s.cfg.MaxSize {
+			break
+		}
+
+		if err := removeEntry(e); err != nil {
+			return err
+		}
+
+		total -= e.Size
+	}
+
+	return nil
+}
+
+// expired returns 'true' if an entry with the given key created at
+// the given time exceeds the configured TTL. Entries referencing an
+// image by digest never expire since their content cannot change.
+func (s *DiskStore) expired(key string, createdAt time.Time) bool {
+	if s.cfg.TTL <= 0 || isDigestReference(key) {
+		return false
+	}
+
+	return time.Since(createdAt) > s.cfg.TTL
+}
+
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+diskStoreExt)
+}
+
+func removeEntry(e DiskStoreEntry) error {
+	if err := os.Remove(e.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing cache entry: %w", err)
+	}
+
+	return nil
+}
+
+func isDigestReference(img string) bool {
+	return strings.Contains(img, "@sha256:")
+}
+
+type DiskStoreConfig struct {
+	// TTL is the maximum age of entries referencing images by
+	// tag. Entries never expire if the TTL is not positive.
+	TTL time.Duration
+	// MaxSize is the maximum total size of all entries in bytes.
+	// The size is unlimited if MaxSize is not positive.
+	MaxSize int64
+}
+
+func (c *DiskStoreConfig) Option(opts ...DiskStoreOption) {
+	for _, opt := range opts {
+		opt.ConfigureDiskStore(c)
+	}
+}
+
+type DiskStoreOption interface {
+	ConfigureDiskStore(*DiskStoreConfig)
+}
+
+type WithTTL time.Duration
+
+func (w WithTTL) ConfigureDiskStore(c *DiskStoreConfig) { c.TTL = time.Duration(w) }
+
+type WithMaxSize int64
+
+func (w WithMaxSize) ConfigureDiskStore(c *DiskStoreConfig) { c.MaxSize = int64(w) }
+
+const (
+	bundleCacheDir = "bundles"
+	indexCacheDir  = "indexes"
+)
+
+// NewDiskCacheStores returns the stores used to persist bundles and
+// index listings below the given cache directory.
+func NewDiskCacheStores(dir string, opts ...DiskStoreOption) (bundles, indexes *DiskStore) {
+	return NewDiskStore(filepath.Join(dir, bundleCacheDir), BundleCodec{}, opts...),
+		NewDiskStore(filepath.Join(dir, indexCacheDir), IndexCodec{}, opts...)
+}
+
+// WithDiskCache persists extracted bundles and index listings below
+// the given directory so that they are reused across invocations.
+// It must be given after WithLog for the logger to be applied.
+func WithDiskCache(dir string, opts ...DiskStoreOption) MainExtractorOpt {
+	return func(e *MainExtractor) {
+		bundles, indexes := NewDiskCacheStores(dir, opts...)
+
+		indexOpts := []IndexExtractorOpt{
+			WithIndexCache(NewIndexCacheImpl(WithStore{indexes})),
+		}
+
+		bundleOpts := []BundleExtractorOpt{
+			WithBundleCache(NewBundleCacheImpl(WithStore{bundles})),
+		}
+
+		if e.Log != nil {
+			indexOpts = append(indexOpts, WithIndexLog(e.Log))
+			bundleOpts = append(bundleOpts, WithBundleLog(e.Log))
+		}
+
+		e.Index = NewIndexExtractor(indexOpts...)
+		e.Bundle = NewBundleExtractor(bundleOpts...)
+	}
+}
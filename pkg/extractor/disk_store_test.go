@@ -0,0 +1,148 @@
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStoreInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Store), new(DiskStore))
+	require.Implements(t, new(Codec), new(BundleCodec))
+	require.Implements(t, new(Codec), new(IndexCodec))
+}
+
+func TestDiskStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	bundle := operator.Bundle{
+		Name:     "reference-addon.v0.1.0",
+		Version:  "0.1.0",
+		Channels: []string{"alpha"},
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: "reference-addon.v0.1.0",
+		},
+	}
+
+	const img = "quay.io/osd-addons/reference-addon-bundle@sha256:abc"
+
+	require.NoError(t, NewDiskStore(dir, BundleCodec{}).Write(img, bundle))
+
+	// a new store sharing the directory simulates a later invocation
+	data, ok := NewDiskStore(dir, BundleCodec{}).Read(img)
+	require.True(t, ok)
+	assert.Equal(t, bundle, data)
+
+	_, ok = NewDiskStore(dir, BundleCodec{}).Read("quay.io/osd-addons/other-bundle:latest")
+	assert.False(t, ok)
+}
+
+func TestDiskStoreTTL(t *testing.T) {
+	t.Parallel()
+
+	store := NewDiskStore(t.TempDir(), IndexCodec{}, WithTTL(time.Millisecond))
+
+	const (
+		tagged   = "quay.io/osd-addons/reference-addon-index:latest"
+		digested = "quay.io/osd-addons/reference-addon-index@sha256:abc"
+	)
+
+	pkgs := map[string][]string{"reference-addon": {"bundle-a", "bundle-b"}}
+
+	require.NoError(t, store.Write(tagged, pkgs))
+	require.NoError(t, store.Write(digested, pkgs))
+
+	time.Sleep(5 * time.Millisecond)
+
+	entries, err := store.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	_, ok := store.Read(tagged)
+	assert.False(t, ok, "entries referencing a tag expire")
+
+	data, ok := store.Read(digested)
+	require.True(t, ok, "entries referencing a digest never expire")
+	assert.Equal(t, pkgs, data)
+
+	entries, err = store.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, digested, entries[0].Key)
+}
+
+func TestDiskStoreMaxSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pkgs := map[string][]string{"reference-addon": {"bundle-a"}}
+
+	unlimited := NewDiskStore(dir, IndexCodec{})
+	require.NoError(t, unlimited.Write("entry-a", pkgs))
+
+	entries, err := unlimited.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// mark the first entry as least recently used
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(entries[0].path, past, past))
+
+	limited := NewDiskStore(dir, IndexCodec{}, WithMaxSize(entries[0].Size))
+	require.NoError(t, limited.Write("entry-b", pkgs))
+
+	_, ok := limited.Read("entry-a")
+	assert.False(t, ok, "least recently used entry is evicted")
+
+	_, ok = limited.Read("entry-b")
+	assert.True(t, ok)
+}
+
+func TestDiskStoreCorruptEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store := NewDiskStore(dir, IndexCodec{})
+
+	require.NoError(t, store.Write("index", map[string][]string{}))
+	require.NoError(t, os.WriteFile(store.path("index"), []byte("{"), 0o644))
+
+	_, ok := store.Read("index")
+	assert.False(t, ok)
+
+	_, err := os.Stat(store.path("index"))
+	assert.True(t, os.IsNotExist(err), "corrupt entries are removed")
+}
+
+func TestDiskStorePruneAndClear(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	store := NewDiskStore(dir, IndexCodec{}, WithTTL(time.Millisecond))
+
+	require.NoError(t, store.Write("tagged:latest", map[string][]string{}))
+	require.NoError(t, store.Write("digested@sha256:abc", map[string][]string{}))
+
+	time.Sleep(5 * time.Millisecond)
+
+	require.NoError(t, store.Prune())
+
+	entries, err := store.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, store.Clear())
+
+	entries, err = store.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
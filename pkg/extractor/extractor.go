@@ -16,6 +16,16 @@ type MainExtractor struct {
 	Log    logrus.FieldLogger
 	Index  IndexExtractor
 	Bundle BundleExtractor
+	// Resolver resolves index images referenced by tag to their
+	// current digest so that cached listings of moved tags are not
+	// reused. Index images are used as is if no Resolver is set.
+	Resolver DigestResolver
+}
+
+// DigestResolver resolves image references to the digest
+// of the manifest they currently point to.
+type DigestResolver interface {
+	ResolveDigest(ctx context.Context, img string) (string, error)
 }
 
 // New - creates a new mainExtractor, with the provided options. Order of provided
@@ -59,6 +69,12 @@ func WithBundleExtractor(bundleExtractor BundleExtractor) MainExtractorOpt {
 	}
 }
 
+func WithDigestResolver(resolver DigestResolver) MainExtractorOpt {
+	return func(e *MainExtractor) {
+		e.Resolver = resolver
+	}
+}
+
 func WithLog(log logrus.FieldLogger) MainExtractorOpt {
 	return func(e *MainExtractor) {
 		e.Log = log
@@ -82,7 +98,7 @@ func (e *MainExtractor) ExtractBundles(ctx context.Context, indexImage string, p
 		return nil, err
 	}
 
-	bundleImages, err := e.Index.ExtractBundleImages(ctx, indexImage, pkgName)
+	bundleImages, err := e.Index.ExtractBundleImages(ctx, e.resolve(ctx, indexImage), pkgName)
 	if err != nil {
 		e.Log.Errorf("failed to extract bundles: %w", err)
 		return nil, err
@@ -102,7 +118,7 @@ func (e *MainExtractor) ExtractAllBundles(ctx context.Context, indexImage string
 		return nil, err
 	}
 
-	bundleImages, err := e.Index.ExtractAllBundleImages(ctx, indexImage)
+	bundleImages, err := e.Index.ExtractAllBundleImages(ctx, e.resolve(ctx, indexImage))
 	if err != nil {
 		e.Log.Errorf("failed to extract all bundles: %w", err)
 		return nil, err
@@ -138,6 +154,29 @@ func (e *MainExtractor) extractBundlesConcurrent(ctx context.Context, bundleImag
 	return res, nil
 }
 
+// resolve returns the digest reference the given image currently
+// points to if it is referenced by tag. The image is resolved once per
+// extraction and returned as is if it cannot be resolved.
+func (e *MainExtractor) resolve(ctx context.Context, img string) string {
+	if e.Resolver == nil || isDigestReference(img) {
+		return img
+	}
+
+	ref, err := imageparser.Parse(img)
+	if err != nil {
+		return img
+	}
+
+	digest, err := e.Resolver.ResolveDigest(ctx, img)
+	if err != nil {
+		e.Log.Warnf("resolving digest of '%s', using tag: %v", img, err)
+
+		return img
+	}
+
+	return ref.Repository() + "@" + digest
+}
+
 func validateIndexImage(indexImage string) error {
 	if indexImage == "" {
 		return errors.New("invalid empty indexImage")
@@ -2,6 +2,7 @@ package extractor
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
@@ -66,3 +67,88 @@ func TestMainExtractorWithDefaultValues(t *testing.T) {
 		})
 	}
 }
+
+func TestMainExtractorResolvesIndexImage(t *testing.T) {
+	const digest = "sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d"
+
+	cases := map[string]struct {
+		indexImage string
+		resolver   DigestResolver
+		expected   string
+		calls      int
+	}{
+		"tag resolved to digest": {
+			indexImage: "quay.io/osd-addons/reference-addon-index:latest",
+			resolver:   &staticResolver{digest: digest},
+			expected:   "quay.io/osd-addons/reference-addon-index@" + digest,
+			calls:      1,
+		},
+		"digest used as is": {
+			indexImage: "quay.io/osd-addons/reference-addon-index@" + digest,
+			resolver:   &staticResolver{digest: digest},
+			expected:   "quay.io/osd-addons/reference-addon-index@" + digest,
+		},
+		"unresolvable tag used as is": {
+			indexImage: "quay.io/osd-addons/reference-addon-index:latest",
+			resolver:   &staticResolver{err: errors.New("unreachable")},
+			expected:   "quay.io/osd-addons/reference-addon-index:latest",
+			calls:      1,
+		},
+		"no resolver": {
+			indexImage: "quay.io/osd-addons/reference-addon-index:latest",
+			expected:   "quay.io/osd-addons/reference-addon-index:latest",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var index recordingIndexExtractor
+
+			opts := []MainExtractorOpt{WithIndexExtractor(&index)}
+			if tc.resolver != nil {
+				opts = append(opts, WithDigestResolver(tc.resolver))
+			}
+
+			_, err := New(opts...).ExtractAllBundles(context.Background(), tc.indexImage)
+			require.NoError(t, err)
+
+			require.Equal(t, []string{tc.expected}, index.images)
+
+			if resolver, ok := tc.resolver.(*staticResolver); ok {
+				require.Equal(t, tc.calls, resolver.calls)
+			}
+		})
+	}
+}
+
+type staticResolver struct {
+	digest string
+	err    error
+	calls  int
+}
+
+func (r *staticResolver) ResolveDigest(_ context.Context, _ string) (string, error) {
+	r.calls++
+
+	return r.digest, r.err
+}
+
+type recordingIndexExtractor struct {
+	images []string
+}
+
+func (e *recordingIndexExtractor) ExtractBundleImages(_ context.Context, indexImage string, _ string) ([]string, error) {
+	e.images = append(e.images, indexImage)
+
+	return nil, nil
+}
+
+func (e *recordingIndexExtractor) ExtractAllBundleImages(_ context.Context, indexImage string) ([]string, error) {
+	e.images = append(e.images, indexImage)
+
+	return nil, nil
+}
@@ -41,10 +41,10 @@ type MetricsFederation struct {
 	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]{0,60}[A-Za-z0-9]$`
 	PortName string `json:"portName" validate:"required"`
 
-	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
 	MatchNames []string `json:"matchNames" validate:"required"`
 
-	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-_./]+$`
+	// Keys and values must match `^[A-Za-z0-9-_./]+$`.
 	MatchLabels map[string]string `json:"matchLabels" validate:"required"`
 }
 
@@ -59,6 +59,7 @@ type MonitoringStack struct {
 	Resources *MonitoringStackResources `json:"resources,omitempty"`
 }
 
+// +kubebuilder:object:generate=true
 type MonitoringStackResources struct {
 	// Represents the cpu/memory resources which would be requested by the Prometheus instances spun up consequently by the MonitoringStack CR in runtime
 	Request *MonitoringStackResource `json:"requests,omitempty"`
@@ -67,6 +68,7 @@ type MonitoringStackResources struct {
 	Limits *MonitoringStackResource `json:"limits,omitempty"`
 }
 
+// +kubebuilder:object:generate=true
 type MonitoringStackResource struct {
 	// Ref: https://github.com/kubernetes/apimachinery/blob/master/pkg/api/resource/quantity.go#L147
 	// +kubebuilder:validation:Pattern=`^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`
@@ -186,6 +186,8 @@ func NewClusterServiceVersionfromRegistryCSV(csv registry.ClusterServiceVersion)
 
 	return ClusterServiceVersion{
 		Name:                              csv.Name,
+		Labels:                            csv.GetLabels(),
+		Annotations:                       csv.GetAnnotations(),
 		OwnedCustomResourceDefinitions:    ownedCRDs,
 		RequiredCustomResourceDefinitions: requiredCRDs,
 		Spec:                              spec,
@@ -194,6 +196,8 @@ func NewClusterServiceVersionfromRegistryCSV(csv registry.ClusterServiceVersion)
 
 type ClusterServiceVersion struct {
 	Name                              string
+	Labels                            map[string]string
+	Annotations                       map[string]string
 	OwnedCustomResourceDefinitions    []CustomResourceDefinition
 	RequiredCustomResourceDefinitions []CustomResourceDefinition
 	Spec                              opsv1alpha1.ClusterServiceVersionSpec
@@ -208,6 +212,40 @@ func NewCustomeResourceDefinitionFromRegistryDefinitionKey(key registry.Definiti
 	}
 }
 
+// SkipRange returns the value of the 'olm.skipRange' annotation
+// or an empty string if the annotation is not set.
+func (c ClusterServiceVersion) SkipRange() string {
+	return c.Annotations[SkipRangeAnnotation]
+}
+
+const SkipRangeAnnotation = "olm.skipRange"
+
+// Architectures returns the CPU architectures declared as supported
+// by the 'operatorframework.io/arch.<arch>' labels sorted by name.
+// OLM assumes 'amd64' if no architecture is declared.
+func (c ClusterServiceVersion) Architectures() []string {
+	var archs []string
+
+	for key, val := range c.Labels {
+		arch, ok := strings.CutPrefix(key, ArchLabelPrefix)
+		if !ok || val != "supported" {
+			continue
+		}
+
+		archs = append(archs, arch)
+	}
+
+	if len(archs) == 0 {
+		return []string{"amd64"}
+	}
+
+	sort.Strings(archs)
+
+	return archs
+}
+
+const ArchLabelPrefix = "operatorframework.io/arch."
+
 type CustomResourceDefinition struct {
 	Name                 string
 	Group, Kind, Version string
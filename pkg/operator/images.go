@@ -0,0 +1,114 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RelatedImageEnvPrefix prefixes the names of container environment
+// variables which reference images the operator deploys.
+const RelatedImageEnvPrefix = "RELATED_IMAGE_"
+
+// ImageReference is an image referenced by a ClusterServiceVersion.
+type ImageReference struct {
+	// Image is the reference as found in the ClusterServiceVersion.
+	Image string
+	// Source describes where the reference was found.
+	Source string
+}
+
+// ImageReferences returns the images referenced by the containers and
+// init containers of the deployments of the ClusterServiceVersion, by
+// the 'RELATED_IMAGE_' environment variables of those containers and by
+// its 'spec.relatedImages'. Images are returned once for every place
+// they are referenced in.
+func (c ClusterServiceVersion) ImageReferences() []ImageReference {
+	var refs []ImageReference
+
+	for _, depSpec := range c.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		podSpec := depSpec.Spec.Template.Spec
+
+		for _, cont := range podSpec.InitContainers {
+			source := fmt.Sprintf("deployment %q init container %q", depSpec.Name, cont.Name)
+
+			refs = append(refs, containerImageReferences(source, cont)...)
+		}
+
+		for _, cont := range podSpec.Containers {
+			source := fmt.Sprintf("deployment %q container %q", depSpec.Name, cont.Name)
+
+			refs = append(refs, containerImageReferences(source, cont)...)
+		}
+	}
+
+	for _, img := range c.Spec.RelatedImages {
+		if img.Image == "" {
+			continue
+		}
+
+		refs = append(refs, ImageReference{
+			Image:  img.Image,
+			Source: fmt.Sprintf("CSV relatedImage %q", img.Name),
+		})
+	}
+
+	return refs
+}
+
+func containerImageReferences(source string, cont corev1.Container) []ImageReference {
+	var refs []ImageReference
+
+	if cont.Image != "" {
+		refs = append(refs, ImageReference{Image: cont.Image, Source: source})
+	}
+
+	for _, env := range cont.Env {
+		if !strings.HasPrefix(env.Name, RelatedImageEnvPrefix) || env.Value == "" {
+			continue
+		}
+
+		refs = append(refs, ImageReference{
+			Image:  env.Value,
+			Source: fmt.Sprintf("%s env %q", source, env.Name),
+		})
+	}
+
+	return refs
+}
+
+// ImageSources is an image together with all
+// places it is referenced in.
+type ImageSources struct {
+	Image   string
+	Sources []string
+}
+
+// GroupImageReferences returns the images of the given references in
+// the order they are first found. Images referenced in several places
+// are returned once together with all of their sources. References to
+// empty images are ignored.
+func GroupImageReferences(refs ...ImageReference) []ImageSources {
+	var (
+		res   []ImageSources
+		index = make(map[string]int)
+	)
+
+	for _, ref := range refs {
+		if ref.Image == "" {
+			continue
+		}
+
+		if i, ok := index[ref.Image]; ok {
+			res[i].Sources = append(res[i].Sources, ref.Source)
+
+			continue
+		}
+
+		index[ref.Image] = len(res)
+		res = append(res, ImageSources{Image: ref.Image, Sources: []string{ref.Source}})
+	}
+
+	return res
+}
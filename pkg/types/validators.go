@@ -7,7 +7,10 @@ import (
 
 type MetaBundle struct {
 	AddonMeta *v1alpha1.AddonMetadataSpec
-	Bundles   []op.Bundle
+	// ImageSet the metadata was combined with. Nil for
+	// addons referencing an indexImage directly.
+	ImageSet *v1alpha1.AddonImageSetSpec
+	Bundles  []op.Bundle
 }
 
 func NewMetaBundle(addonMeta *v1alpha1.AddonMetadataSpec, bundles []op.Bundle) *MetaBundle {
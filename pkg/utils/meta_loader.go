@@ -8,8 +8,10 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
 
 	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 )
@@ -37,32 +39,52 @@ func NewMetaLoader(addonDir, env, version string) MetaLoader {
 
 // Load - loads the addon metadata and imageSet
 func (l defaultMetaLoader) Load() (*addonsv1alpha1.AddonMetadataSpec, error) {
+	meta, _, err := l.load()
+
+	return meta, err
+}
+
+// LoadWithImageSet - loads the addon metadata combined with its imageSet
+// and the imageSet itself. The imageSet is nil for addons setting the
+// 'indexImage' field.
+func LoadWithImageSet(addonDir, env, version string) (*addonsv1alpha1.AddonMetadataSpec, *addonsv1alpha1.AddonImageSetSpec, error) {
+	l := defaultMetaLoader{
+		AddonDir:  addonDir,
+		AddonName: path.Base(addonDir),
+		Env:       env,
+		Version:   version,
+	}
+
+	return l.load()
+}
+
+func (l defaultMetaLoader) load() (*addonsv1alpha1.AddonMetadataSpec, *addonsv1alpha1.AddonImageSetSpec, error) {
 	meta, err := l.readMeta()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// invalid - legacy addon
 	if meta.IndexImage == nil && meta.ImageSetVersion == nil {
-		return nil, errors.New("No validation support for legacy addon. Please use the imageSet feature.")
+		return nil, nil, errors.New("No validation support for legacy addon. Please use the imageSet feature.")
 	}
 	// invalid - misconfiguration
 	if meta.IndexImage != nil && meta.ImageSetVersion != nil {
-		return nil, errors.New("Can't set both the 'indexImage' and the 'imageSetVersion' field.")
+		return nil, nil, errors.New("Can't set both the 'indexImage' and the 'imageSetVersion' field.")
 	}
 	// imageSet
 	if meta.ImageSetVersion != nil {
 		imageSet, err := l.readImageSet(*meta.ImageSetVersion)
 		if err != nil {
-			return nil, fmt.Errorf("Could not read imageSet, got %v.\n", err)
+			return nil, nil, fmt.Errorf("Could not read imageSet, got %v.\n", err)
 		}
 		combinedMeta, err := meta.CombineWithImageSet(imageSet)
 		if err != nil {
-			return nil, fmt.Errorf("Could not combine metadata and imageset, got %v.", err)
+			return nil, nil, fmt.Errorf("Could not combine metadata and imageset, got %v.", err)
 		}
-		return combinedMeta, nil
+		return combinedMeta, imageSet, nil
 	}
 
-	return meta, nil
+	return meta, nil, nil
 }
 
 func (l defaultMetaLoader) readMeta() (*addonsv1alpha1.AddonMetadataSpec, error) {
@@ -77,7 +99,90 @@ func (l defaultMetaLoader) readMeta() (*addonsv1alpha1.AddonMetadataSpec, error)
 }
 
 func (l defaultMetaLoader) getMetadataPath() string {
-	return filepath.Join(l.AddonDir, "metadata", l.Env, "addon.yaml")
+	return MetadataPath(l.AddonDir, l.Env)
+}
+
+// MetadataPath returns the path of the addon.yaml file for the given
+// addon directory and environment.
+func MetadataPath(addonDir, env string) string {
+	return filepath.Join(addonDir, "metadata", env, "addon.yaml")
+}
+
+// ImageSetPath returns the path of the imageset file matching the given
+// addon directory, environment and "MAJOR.MINOR.PATCH" version.
+func ImageSetPath(addonDir, env, version string) string {
+	return filepath.Join(imageSetDir(addonDir, env), fmt.Sprintf("%s.v%s.yaml", path.Base(addonDir), version))
+}
+
+func imageSetDir(addonDir, env string) string {
+	return filepath.Join(addonDir, "addonimagesets", env)
+}
+
+// Envs returns the sorted names of all environments for which the
+// given addon directory contains an addon.yaml file.
+func Envs(addonDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(addonDir, "metadata"))
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata directory: %w", err)
+	}
+
+	var envs []string
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		if _, err := os.Stat(MetadataPath(addonDir, e.Name())); errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("checking metadata for env %q: %w", e.Name(), err)
+		}
+
+		envs = append(envs, e.Name())
+	}
+
+	sort.Strings(envs)
+
+	return envs, nil
+}
+
+// ImageSetVersions returns the "MAJOR.MINOR.PATCH" versions of all
+// imagesets of the given addon directory and environment sorted in
+// ascending order. No versions are returned if the environment has
+// no imageset directory.
+func ImageSetVersions(addonDir, env string) ([]string, error) {
+	entries, err := os.ReadDir(imageSetDir(addonDir, env))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading imageset directory: %w", err)
+	}
+
+	prefix := path.Base(addonDir) + ".v"
+
+	var versions []string
+
+	for _, e := range entries {
+		name := e.Name()
+
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || filepath.Ext(name) != ".yaml" {
+			continue
+		}
+
+		version := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".yaml")
+		if !semver.IsValid("v" + version) {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare("v"+versions[i], "v"+versions[j]) < 0
+	})
+
+	return versions, nil
 }
 
 func (l defaultMetaLoader) readImageSet(defaultVersion string) (*addonsv1alpha1.AddonImageSetSpec, error) {
@@ -86,11 +191,31 @@ func (l defaultMetaLoader) readImageSet(defaultVersion string) (*addonsv1alpha1.
 	if err != nil {
 		return nil, err
 	}
+	return readImageSet(imageSetPath, l.AddonName)
+}
+
+// LoadImageSet - loads the imageSet of the given addon directory and
+// environment. The version must either match "MAJOR.MINOR.PATCH" or be
+// "latest".
+func LoadImageSet(addonDir, env, version string) (*addonsv1alpha1.AddonImageSetSpec, error) {
+	l := defaultMetaLoader{
+		AddonDir:  addonDir,
+		AddonName: path.Base(addonDir),
+		Env:       env,
+	}
+	imageSetPath, err := l.getImagesetPath(version)
+	if err != nil {
+		return nil, err
+	}
+	return readImageSet(imageSetPath, l.AddonName)
+}
+
+func readImageSet(imageSetPath, addonName string) (*addonsv1alpha1.AddonImageSetSpec, error) {
 	data, err := os.ReadFile(imageSetPath)
 	if err != nil {
 		return nil, err
 	}
-	log.Debugf("Raw imageSet read from addon: %v. \n%v\n", l.AddonName, string(data))
+	log.Debugf("Raw imageSet read from addon: %v. \n%v\n", addonName, string(data))
 	imageSet := &addonsv1alpha1.AddonImageSetSpec{}
 	err = imageSet.FromYAML(data)
 	return imageSet, err
@@ -106,16 +231,15 @@ func (l defaultMetaLoader) getImageSetVersion(defaultVersion string) string {
 }
 
 func (l defaultMetaLoader) getImagesetPath(version string) (string, error) {
-	baseDir := filepath.Join(l.AddonDir, "addonimagesets", l.Env)
-	target := fmt.Sprintf("%s.v%s.yaml", l.AddonName, version)
 	if version == "latest" {
+		baseDir := imageSetDir(l.AddonDir, l.Env)
 		latest, err := GetLatestImageSetVersion(baseDir)
 		if err != nil {
 			return "", err
 		}
-		target = latest
+		return filepath.Join(baseDir, latest), nil
 	}
-	return filepath.Join(baseDir, target), nil
+	return ImageSetPath(l.AddonDir, l.Env, version), nil
 }
 
 func GetLatestImageSetVersion(dir string) (string, error) {
@@ -84,3 +84,44 @@ func TestMetaLoaderImageSet(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvs(t *testing.T) {
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	envs, err := utils.Envs(refAddonStage.ImageSetDir())
+	require.NoError(t, err)
+	require.Equal(t, []string{"stage"}, envs)
+}
+
+func TestImageSetVersions(t *testing.T) {
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	versions, err := utils.ImageSetVersions(refAddonStage.ImageSetDir(), "stage")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.0.1", "0.0.2", "0.0.5"}, versions)
+
+	versions, err = utils.ImageSetVersions(refAddonStage.IndexImageDir(), "stage")
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
+
+func TestLoadImageSet(t *testing.T) {
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	for _, version := range []string{"latest", "0.0.1"} {
+		imageSet, err := utils.LoadImageSet(refAddonStage.ImageSetDir(), "stage", version)
+		require.NoError(t, err)
+
+		expectedImageSet, err := refAddonStage.GetImageSet(version)
+		require.NoError(t, err)
+
+		require.Equal(t, expectedImageSet.Name, imageSet.Name)
+		require.Equal(t, expectedImageSet.IndexImage, imageSet.IndexImage)
+	}
+
+	_, err = utils.LoadImageSet(refAddonStage.ImageSetDir(), "stage", "9.9.9")
+	require.Error(t, err)
+}
@@ -25,6 +25,7 @@ func NewDefaultChannel(deps validator.Dependencies) (validator.Validator, error)
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
 	)
 	if err != nil {
 		return nil, err
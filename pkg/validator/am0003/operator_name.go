@@ -15,6 +15,9 @@ const (
 	code = 3
 	name = "operator_name"
 	desc = "Validate the operatorName matches csv.Name, csv.Replaces and bundle package annotation."
+
+	// defaultChannelCode is the code of the AM0001 default_channel validator.
+	defaultChannelCode = 1
 )
 
 func init() {
@@ -26,6 +29,9 @@ func NewOperatorName(deps validator.Dependencies) (validator.Validator, error) {
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
+		// bundles are only checked once their channels are validated
+		validator.BasePrerequisites(defaultChannelCode),
 	)
 	if err != nil {
 		return nil, err
@@ -24,20 +24,21 @@ func NewTestHarnessExists(deps validator.Dependencies) (validator.Validator, err
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(description),
+		validator.BaseCapabilities(validator.CapabilityNetwork, validator.CapabilityRegistry),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TestHarnessExists{
-		Base: base,
-		quay: deps.QuayClient,
+		Base:     base,
+		registry: deps.RegistryClient,
 	}, nil
 }
 
 type TestHarnessExists struct {
 	*validator.Base
-	quay validator.QuayClient
+	registry validator.RegistryClient
 }
 
 func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
@@ -50,7 +51,7 @@ func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) valida
 		return t.Fail("Testharness image is not in the quay.io registry")
 	}
 
-	ok, err := t.quay.HasReference(ctx, ref)
+	ok, err := t.registry.HasReference(ctx, ref)
 	if err != nil {
 		return t.Error(err)
 	}
@@ -14,8 +14,8 @@ import (
 func TestTestHarnessExistsValid(t *testing.T) {
 	t.Parallel()
 
-	quay := testutils.NewMockQuayClient()
-	quay.
+	registry := testutils.NewMockRegistryClient()
+	registry.
 		On("HasReference",
 			context.Background(),
 			getRef(t, "quay.io/miwilson/addon-samples"),
@@ -65,7 +65,7 @@ func TestTestHarnessExistsValid(t *testing.T) {
 
 	tester := testutils.NewValidatorTester(t,
 		NewTestHarnessExists,
-		testutils.ValidatorTesterQuayClient(quay),
+		testutils.ValidatorTesterRegistryClient(registry),
 	)
 	tester.TestValidBundles(bundles)
 }
@@ -73,8 +73,8 @@ func TestTestHarnessExistsValid(t *testing.T) {
 func TestTestHarnessExistsInvalid(t *testing.T) {
 	t.Parallel()
 
-	quay := testutils.NewMockQuayClient()
-	quay.
+	registry := testutils.NewMockRegistryClient()
+	registry.
 		On("HasReference",
 			context.Background(),
 			getRef(t, "abcd"),
@@ -93,7 +93,7 @@ func TestTestHarnessExistsInvalid(t *testing.T) {
 
 	tester := testutils.NewValidatorTester(t,
 		NewTestHarnessExists,
-		testutils.ValidatorTesterQuayClient(quay),
+		testutils.ValidatorTesterRegistryClient(registry),
 	)
 	tester.TestInvalidBundles(map[string]types.MetaBundle{
 		"invalid url": {
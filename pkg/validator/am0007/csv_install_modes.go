@@ -24,6 +24,7 @@ func NewCSVInstallModes(deps validator.Dependencies) (validator.Validator, error
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
 	)
 	if err != nil {
 		return nil, err
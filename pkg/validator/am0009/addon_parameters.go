@@ -3,10 +3,14 @@ package am0009
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func init() {
@@ -43,40 +47,187 @@ func (a *AddonParamters) Run(ctx context.Context, mb types.MetaBundle) validator
 	if addonParams == nil {
 		return a.Success()
 	}
+
+	var msgs []string
+
+	ids := make(map[string]bool)
+	orders := make(map[int]string)
+
 	for _, param := range *addonParams {
-		validation := param.Validation
-		options := param.Options
-		defaultValue := param.DefaultValue
+		if ids[param.ID] {
+			msgs = append(msgs, fmt.Sprintf("parameter id %q is not unique", param.ID))
+		}
+
+		ids[param.ID] = true
 
-		if validation != nil && options != nil {
-			return a.Fail("validation and options can't both be set")
+		if param.Order != nil {
+			if other, ok := orders[*param.Order]; ok {
+				msgs = append(msgs, fmt.Sprintf(
+					"parameter %q has order %d which is already used by parameter %q", param.ID, *param.Order, other,
+				))
+			} else {
+				orders[*param.Order] = param.ID
+			}
 		}
 
-		if defaultValue != nil {
-			if validation != nil {
-				r, err := regexp.Compile(*validation)
-				if err != nil {
-					return a.Error(fmt.Errorf("failed parse `validation` as regex: %w", err))
-				}
-				if !r.MatchString(*defaultValue) {
-					msg := fmt.Sprintf("defaultValue %s didn't match its validation", *defaultValue)
-					if param.ValidationErrMsg != nil {
-						return a.Fail(fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg))
-					}
-					return a.Fail(msg)
-				}
-				return a.Success()
+		msgs = append(msgs, checkParameter(param)...)
+	}
+
+	if len(msgs) > 0 {
+		return a.Fail(msgs...)
+	}
+
+	return a.Success()
+}
+
+// checkParameter returns the failure messages of a single parameter.
+func checkParameter(param ocmv1.AddOnParameter) []string {
+	var msgs []string
+
+	isValid, ok := valueTypes[param.ValueType]
+	if !ok {
+		msgs = append(msgs, fmt.Sprintf("parameter %q has unknown value_type %q", param.ID, param.ValueType))
+	}
+
+	validation := param.Validation
+	options := param.Options
+
+	if validation != nil && options != nil {
+		msgs = append(msgs, fmt.Sprintf("parameter %q can't set both validation and options", param.ID))
+	}
+
+	var pattern *regexp.Regexp
+
+	if validation != nil {
+		var err error
+
+		pattern, err = regexp.Compile(*validation)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("parameter %q has invalid validation regex: %v", param.ID, err))
+		}
+	}
+
+	if options != nil {
+		values := make(map[string]bool, len(*options))
+
+		for _, opt := range *options {
+			if values[opt.Value] {
+				msgs = append(msgs, fmt.Sprintf("parameter %q has duplicate option value %q", param.ID, opt.Value))
 			}
 
-			if options != nil {
-				for _, opt := range *options {
-					if *defaultValue == opt.Value {
-						return a.Success()
-					}
-				}
-				return a.Fail(fmt.Sprintf("defaultValue '%s' not found in `options`", *defaultValue))
+			values[opt.Value] = true
+
+			if isValid != nil && !isValid(opt.Value) {
+				msgs = append(msgs, fmt.Sprintf(
+					"parameter %q has option value %q which is not a valid %s", param.ID, opt.Value, param.ValueType,
+				))
 			}
 		}
 	}
-	return a.Success()
+
+	if param.DefaultValue != nil {
+		msgs = append(msgs, checkDefaultValue(param, isValid, pattern)...)
+	}
+
+	if param.Conditions != nil {
+		for _, cond := range *param.Conditions {
+			if _, ok := conditionResources[cond.Resource]; !ok {
+				msgs = append(msgs, fmt.Sprintf(
+					"parameter %q has condition on unknown resource %q", param.ID, cond.Resource,
+				))
+			}
+
+			if len(cond.Data) == 0 {
+				msgs = append(msgs, fmt.Sprintf(
+					"parameter %q has condition on resource %q without data", param.ID, cond.Resource,
+				))
+			}
+		}
+	}
+
+	return msgs
+}
+
+// checkDefaultValue returns the failure messages of the default value
+// of a parameter which must parse for the parameter's value type, match
+// its validation and be one of its options.
+func checkDefaultValue(param ocmv1.AddOnParameter, isValid valueValidator, pattern *regexp.Regexp) []string {
+	var msgs []string
+
+	defaultValue := *param.DefaultValue
+
+	if isValid != nil && !isValid(defaultValue) {
+		msgs = append(msgs, fmt.Sprintf(
+			"parameter %q has defaultValue %q which is not a valid %s", param.ID, defaultValue, param.ValueType,
+		))
+	}
+
+	if pattern != nil && !pattern.MatchString(defaultValue) {
+		msg := fmt.Sprintf("parameter %q has defaultValue %q which didn't match its validation", param.ID, defaultValue)
+		if param.ValidationErrMsg != nil {
+			msg = fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg)
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	if param.Options != nil && !hasOption(*param.Options, defaultValue) {
+		msgs = append(msgs, fmt.Sprintf(
+			"parameter %q has defaultValue %q which is not found in `options`", param.ID, defaultValue,
+		))
+	}
+
+	return msgs
+}
+
+func hasOption(options []ocmv1.AddOnParameterOption, value string) bool {
+	for _, opt := range options {
+		if opt.Value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type valueValidator func(string) bool
+
+// valueTypes validate values of the supported value types.
+// Parameters without a value type are treated as strings.
+var valueTypes = map[ocmv1.AddOnParameterValueType]valueValidator{
+	"":                                    isString,
+	ocmv1.AddOnParameterValueTypeString:   isString,
+	ocmv1.AddOnParameterValueTypeNumber:   isNumber,
+	ocmv1.AddOnParameterValueTypeBoolean:  isBoolean,
+	ocmv1.AddOnParameterValueTypeCIDR:     isCIDR,
+	ocmv1.AddOnParameterValueTypeResource: isResource,
+}
+
+func isString(string) bool { return true }
+
+func isNumber(val string) bool {
+	_, err := strconv.ParseFloat(val, 64)
+
+	return err == nil
+}
+
+func isBoolean(val string) bool { return val == "true" || val == "false" }
+
+func isCIDR(val string) bool {
+	_, _, err := net.ParseCIDR(val)
+
+	return err == nil
+}
+
+// isResource validates resource values as kubernetes quantities.
+func isResource(val string) bool {
+	_, err := resource.ParseQuantity(val)
+
+	return err == nil
+}
+
+var conditionResources = map[ocmv1.AddOnRequirementResourceType]struct{}{
+	ocmv1.AddOnRequirementResourceTypeCluster:     {},
+	ocmv1.AddOnRequirementResourceTypeAddOn:       {},
+	ocmv1.AddOnRequirementResourceTypeMachinePool: {},
 }
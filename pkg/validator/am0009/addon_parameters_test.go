@@ -8,7 +8,9 @@ import (
 	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	utils "github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
 func TestAddonParametersValid(t *testing.T) {
@@ -70,6 +72,47 @@ func TestAddonParametersValid(t *testing.T) {
 				ID: "addon-parameters-not-specified",
 			},
 		},
+		"every parameter valid": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ID: "typed-parameters",
+				AddOnParameters: &[]ocmv1.AddOnParameter{
+					{
+						ID:           "replicas",
+						ValueType:    ocmv1.AddOnParameterValueTypeNumber,
+						DefaultValue: testutils.GetStringLiteralRef("3"),
+						Order:        intRef(0),
+					},
+					{
+						ID:           "debug",
+						ValueType:    ocmv1.AddOnParameterValueTypeBoolean,
+						DefaultValue: testutils.GetStringLiteralRef("false"),
+						Order:        intRef(1),
+						Conditions: &[]ocmv1.AddOnResourceRequirement{
+							{
+								Resource: ocmv1.AddOnRequirementResourceTypeCluster,
+								Data: ocmv1.AddOnRequirementData{
+									"cloud_provider.id": apiextensionsv1.JSON{Raw: []byte(`"aws"`)},
+								},
+							},
+						},
+					},
+					{
+						ID:           "pod-cidr",
+						ValueType:    ocmv1.AddOnParameterValueTypeCIDR,
+						DefaultValue: testutils.GetStringLiteralRef("10.128.0.0/14"),
+					},
+					{
+						ID:        "storage",
+						ValueType: ocmv1.AddOnParameterValueTypeResource,
+						Options: &[]ocmv1.AddOnParameterOption{
+							{Name: "1 TiB", Value: "1Ti"},
+							{Name: "4 TiB", Value: "4Ti"},
+						},
+						DefaultValue: testutils.GetStringLiteralRef("1Ti"),
+					},
+				},
+			},
+		},
 	} {
 		bundles[name] = bundle
 	}
@@ -142,3 +185,136 @@ func TestAddonParametersInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestAddonParametersFailureMsgs(t *testing.T) {
+	t.Parallel()
+
+	tester := utils.NewValidatorTester(t, NewAddonParameters)
+
+	for name, tc := range map[string]struct {
+		Params   []ocmv1.AddOnParameter
+		Expected []string
+	}{
+		"every parameter is checked": {
+			Params: []ocmv1.AddOnParameter{
+				{
+					ID:           "first",
+					Validation:   testutils.GetStringLiteralRef("^[a-z]+$"),
+					DefaultValue: testutils.GetStringLiteralRef("valid"),
+				},
+				{
+					ID:           "second",
+					Validation:   testutils.GetStringLiteralRef("^[a-z]+$"),
+					DefaultValue: testutils.GetStringLiteralRef("INVALID"),
+				},
+			},
+			Expected: []string{
+				`parameter "second" has defaultValue "INVALID" which didn't match its validation`,
+			},
+		},
+		"duplicate ids and orders": {
+			Params: []ocmv1.AddOnParameter{
+				{ID: "size", Order: intRef(1)},
+				{ID: "size", Order: intRef(2)},
+				{ID: "other", Order: intRef(1)},
+			},
+			Expected: []string{
+				`parameter id "size" is not unique`,
+				`parameter "other" has order 1 which is already used by parameter "size"`,
+			},
+		},
+		"default values not matching their value type": {
+			Params: []ocmv1.AddOnParameter{
+				{
+					ID:           "replicas",
+					ValueType:    ocmv1.AddOnParameterValueTypeNumber,
+					DefaultValue: testutils.GetStringLiteralRef("three"),
+				},
+				{
+					ID:           "debug",
+					ValueType:    ocmv1.AddOnParameterValueTypeBoolean,
+					DefaultValue: testutils.GetStringLiteralRef("yes"),
+				},
+				{
+					ID:           "pod-cidr",
+					ValueType:    ocmv1.AddOnParameterValueTypeCIDR,
+					DefaultValue: testutils.GetStringLiteralRef("10.128.0.0"),
+				},
+				{
+					ID:           "storage",
+					ValueType:    ocmv1.AddOnParameterValueTypeResource,
+					DefaultValue: testutils.GetStringLiteralRef("1 TiB"),
+				},
+				{
+					ID:        "unknown",
+					ValueType: "list",
+				},
+			},
+			Expected: []string{
+				`parameter "replicas" has defaultValue "three" which is not a valid number`,
+				`parameter "debug" has defaultValue "yes" which is not a valid boolean`,
+				`parameter "pod-cidr" has defaultValue "10.128.0.0" which is not a valid cidr`,
+				`parameter "storage" has defaultValue "1 TiB" which is not a valid resource`,
+				`parameter "unknown" has unknown value_type "list"`,
+			},
+		},
+		"invalid options": {
+			Params: []ocmv1.AddOnParameter{
+				{
+					ID:        "size",
+					ValueType: ocmv1.AddOnParameterValueTypeNumber,
+					Options: &[]ocmv1.AddOnParameterOption{
+						{Name: "small", Value: "1"},
+						{Name: "also small", Value: "1"},
+						{Name: "large", Value: "large"},
+					},
+					DefaultValue: testutils.GetStringLiteralRef("2"),
+				},
+			},
+			Expected: []string{
+				`parameter "size" has duplicate option value "1"`,
+				`parameter "size" has option value "large" which is not a valid number`,
+				"parameter \"size\" has defaultValue \"2\" which is not found in `options`",
+			},
+		},
+		"invalid conditions": {
+			Params: []ocmv1.AddOnParameter{
+				{
+					ID: "aws-only",
+					Conditions: &[]ocmv1.AddOnResourceRequirement{
+						{
+							Resource: "node",
+							Data: ocmv1.AddOnRequirementData{
+								"cloud_provider.id": apiextensionsv1.JSON{Raw: []byte(`"aws"`)},
+							},
+						},
+						{
+							Resource: ocmv1.AddOnRequirementResourceTypeCluster,
+						},
+					},
+				},
+			},
+			Expected: []string{
+				`parameter "aws-only" has condition on unknown resource "node"`,
+				`parameter "aws-only" has condition on resource "cluster" without data`,
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tester.TestSingleBundle(types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					ID:              "addon-parameters",
+					AddOnParameters: &tc.Params,
+				},
+			})
+			assert.False(t, res.IsSuccess())
+			assert.ElementsMatch(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func intRef(i int) *int { return &i }
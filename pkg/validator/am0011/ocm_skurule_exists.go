@@ -23,6 +23,7 @@ func NewOCMSKURuleExists(deps validator.Dependencies) (validator.Validator, erro
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityNetwork, validator.CapabilityOCM),
 	)
 	if err != nil {
 		return nil, err
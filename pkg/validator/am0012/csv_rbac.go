@@ -25,6 +25,7 @@ func NewCSVRBAC(opt validator.Dependencies) (validator.Validator, error) {
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
 	)
 	if err != nil {
 		return nil, err
@@ -42,7 +43,7 @@ type CSVRBAC struct {
 func (v *CSVRBAC) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
 	bundle, ok := operator.HeadBundle(mb.Bundles...)
 	if !ok {
-		return v.Success()
+		return v.Skip("no bundles found for the addon's operator")
 	}
 
 	csv := bundle.ClusterServiceVersion
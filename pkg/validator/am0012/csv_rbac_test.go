@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
@@ -37,3 +38,16 @@ func TestCSVRBACInvalid(t *testing.T) {
 	})
 
 }
+
+func TestCSVRBACSkipped(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCSVRBAC)
+	tester.TestSkippedBundles(map[string]types.MetaBundle{
+		"no bundles": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+		},
+	})
+}
@@ -25,6 +25,8 @@ func NewCSVDeployment(deps validator.Dependencies) (validator.Validator, error)
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseSeverity(validator.SeverityWarning),
+		validator.BaseCapabilities(validator.CapabilityBundles),
 	)
 	if err != nil {
 		return nil, err
@@ -46,7 +48,7 @@ func (c *CSVDeployment) Run(ctx context.Context, mb types.MetaBundle) validator.
 
 	bundle, ok := operator.HeadBundle(mb.Bundles...)
 	if !ok {
-		return c.Success()
+		return c.Skip("no bundles found for the addon's operator")
 	}
 
 	csv := bundle.ClusterServiceVersion
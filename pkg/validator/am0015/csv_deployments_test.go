@@ -79,3 +79,16 @@ func TestCSVDeploymentInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestCSVDeploymentSkipped(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCSVDeployment)
+	tester.TestSkippedBundles(map[string]types.MetaBundle{
+		"no bundles": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+		},
+	})
+}
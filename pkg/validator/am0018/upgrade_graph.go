@@ -0,0 +1,351 @@
+package am0018
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewUpgradeGraph)
+}
+
+const (
+	code = 18
+	name = "upgrade_graph"
+	desc = "Ensure the bundles of every channel form a valid upgrade graph"
+)
+
+func NewUpgradeGraph(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradeGraph{
+		Base: base,
+	}, nil
+}
+
+type UpgradeGraph struct {
+	*validator.Base
+}
+
+func (u *UpgradeGraph) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if len(mb.Bundles) == 0 {
+		return u.Skip("no bundles found for the addon's operator")
+	}
+
+	var msgs []string
+
+	graphs := newChannelGraphs(mb.Bundles)
+
+	for _, ch := range sortedKeys(graphs) {
+		msgs = append(msgs, graphs[ch].validate()...)
+	}
+
+	if startingCSV := mb.AddonMeta.StartingCSV; startingCSV != nil && *startingCSV != "" {
+		defaultChannel := mb.AddonMeta.DefaultChannel
+
+		if g, ok := graphs[defaultChannel]; !ok || !g.reachable(*startingCSV) {
+			msgs = append(msgs, fmt.Sprintf(
+				"startingCSV %q is not reachable from the head of the defaultChannel %q.", *startingCSV, defaultChannel,
+			))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return u.Fail(msgs...)
+	}
+
+	return u.Success()
+}
+
+// channelGraph is the upgrade graph of a single channel. Every node
+// is a CSV name and edges point from a bundle to the bundles it
+// replaces or skips.
+type channelGraph struct {
+	channel  string
+	bundles  map[string]operator.Bundle
+	versions map[string]semver.Version
+	edges    map[string][]string
+}
+
+func newChannelGraphs(bundles []operator.Bundle) map[string]*channelGraph {
+	graphs := make(map[string]*channelGraph)
+
+	for _, b := range bundles {
+		for _, ch := range b.Annotations.Channels {
+			if ch == "" {
+				continue
+			}
+
+			g, ok := graphs[ch]
+			if !ok {
+				g = &channelGraph{
+					channel:  ch,
+					bundles:  make(map[string]operator.Bundle),
+					versions: make(map[string]semver.Version),
+					edges:    make(map[string][]string),
+				}
+				graphs[ch] = g
+			}
+
+			g.bundles[b.ClusterServiceVersion.Name] = b
+
+			if v, err := semver.ParseTolerant(b.Version); err == nil {
+				g.versions[b.ClusterServiceVersion.Name] = v
+			}
+		}
+	}
+
+	for _, g := range graphs {
+		g.addEdges()
+	}
+
+	return graphs
+}
+
+func (g *channelGraph) addEdges() {
+	for _, name := range sortedKeys(g.bundles) {
+		csv := g.bundles[name].ClusterServiceVersion
+
+		targets := make(map[string]struct{})
+
+		if replaces := csv.Spec.Replaces; replaces != "" {
+			targets[replaces] = struct{}{}
+		}
+
+		for _, skip := range csv.Spec.Skips {
+			targets[skip] = struct{}{}
+		}
+
+		if skipRange, err := semver.ParseRange(csv.SkipRange()); err == nil {
+			for other, v := range g.versions {
+				if other != name && skipRange(v) {
+					targets[other] = struct{}{}
+				}
+			}
+		}
+
+		for target := range targets {
+			// edges to bundles outside of the channel are
+			// allowed and mark the tail of the channel
+			if _, ok := g.bundles[target]; ok {
+				g.edges[name] = append(g.edges[name], target)
+			}
+		}
+
+		sort.Strings(g.edges[name])
+	}
+}
+
+func (g *channelGraph) validate() []string {
+	var msgs []string
+
+	heads := g.heads()
+
+	if len(heads) > 1 {
+		msgs = append(msgs, fmt.Sprintf(
+			"channel %q has multiple heads: %s.", g.channel, strings.Join(heads, ", "),
+		))
+	}
+
+	if cycle := g.cycle(); len(cycle) > 0 {
+		msgs = append(msgs, fmt.Sprintf(
+			"channel %q contains an upgrade cycle: %s.", g.channel, strings.Join(cycle, " -> "),
+		))
+	}
+
+	if head, ok := g.head(); ok {
+		visited := g.walk(head)
+
+		for _, name := range sortedKeys(g.bundles) {
+			if _, ok := visited[name]; ok || contains(heads, name) {
+				continue
+			}
+
+			msgs = append(msgs, fmt.Sprintf(
+				"bundle %q in channel %q is orphaned: it can't be upgraded to the channel head %q.", name, g.channel, head,
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(g.edges) {
+		from, ok := g.versions[name]
+		if !ok {
+			continue
+		}
+
+		for _, target := range g.edges[name] {
+			if to, ok := g.versions[target]; ok && to.GE(from) {
+				msgs = append(msgs, fmt.Sprintf(
+					"bundle %q in channel %q upgrades from %q which is not an older version.", name, g.channel, target,
+				))
+			}
+		}
+	}
+
+	return msgs
+}
+
+// heads returns the sorted names of all bundles which are neither
+// replaced nor skipped by another bundle of the channel.
+func (g *channelGraph) heads() []string {
+	referenced := make(map[string]struct{})
+
+	for _, targets := range g.edges {
+		for _, t := range targets {
+			referenced[t] = struct{}{}
+		}
+	}
+
+	var heads []string
+
+	for _, name := range sortedKeys(g.bundles) {
+		if _, ok := referenced[name]; !ok {
+			heads = append(heads, name)
+		}
+	}
+
+	return heads
+}
+
+// head returns the head with the highest version which is used as
+// the channel head if a channel has multiple heads.
+func (g *channelGraph) head() (string, bool) {
+	heads := g.heads()
+	if len(heads) == 0 {
+		return "", false
+	}
+
+	head := heads[0]
+
+	for _, h := range heads[1:] {
+		if g.versions[h].GT(g.versions[head]) {
+			head = h
+		}
+	}
+
+	return head, true
+}
+
+// reachable returns 'true' if the given CSV can be upgraded
+// to the channel head.
+func (g *channelGraph) reachable(csvName string) bool {
+	head, ok := g.head()
+	if !ok {
+		return false
+	}
+
+	_, ok = g.walk(head)[csvName]
+
+	return ok
+}
+
+func (g *channelGraph) walk(from string) map[string]struct{} {
+	visited := map[string]struct{}{from: {}}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.edges[cur] {
+			if _, ok := visited[next]; ok {
+				continue
+			}
+
+			visited[next] = struct{}{}
+			queue = append(queue, next)
+		}
+	}
+
+	return visited
+}
+
+// cycle returns the first cycle found in the graph starting and
+// ending with the same CSV name or nil if the graph is acyclic.
+func (g *channelGraph) cycle() []string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	state := make(map[string]int)
+
+	var path []string
+
+	var visit func(string) []string
+
+	visit = func(name string) []string {
+		state[name] = inProgress
+		path = append(path, name)
+
+		for _, next := range g.edges[name] {
+			switch state[next] {
+			case inProgress:
+				for i, n := range path {
+					if n == next {
+						return append(append([]string{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = done
+
+		return nil
+	}
+
+	for _, name := range sortedKeys(g.bundles) {
+		if state[name] != unvisited {
+			continue
+		}
+
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
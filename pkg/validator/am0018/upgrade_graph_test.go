@@ -0,0 +1,191 @@
+package am0018
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeGraphValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"single bundle": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+		),
+		"replaces chain": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			newBundle("0.2.0", "0.1.0", "alpha"),
+			newBundle("0.3.0", "0.2.0", "alpha"),
+		),
+		"replaces outside of channel": newMetaBundle(
+			newBundle("0.2.0", "0.1.0", "alpha"),
+			newBundle("0.3.0", "0.2.0", "alpha"),
+		),
+		"skips": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			newBundle("0.2.0", "", "alpha"),
+			withSkips(newBundle("0.3.0", "0.1.0", "alpha"), "0.2.0"),
+		),
+		"skipRange": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			newBundle("0.2.0", "", "alpha"),
+			withSkipRange(newBundle("0.3.0", "", "alpha"), ">=0.1.0 <0.3.0"),
+		),
+		"multiple channels": newMetaBundle(
+			newBundle("0.1.0", "", "alpha", "stable"),
+			newBundle("0.2.0", "0.1.0", "alpha"),
+		),
+		"startingCSV in defaultChannel": withStartingCSV(newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			newBundle("0.2.0", "0.1.0", "alpha"),
+		), "0.1.0"),
+	})
+}
+
+func TestUpgradeGraphSkipped(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+	tester.TestSkippedBundles(map[string]types.MetaBundle{
+		"no bundles": newMetaBundle(),
+	})
+}
+
+func TestUpgradeGraphInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+	tester.TestInvalidBundles(map[string]types.MetaBundle{
+		"multiple heads": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			newBundle("0.2.0", "", "alpha"),
+		),
+		"cycle": newMetaBundle(
+			newBundle("0.1.0", "0.2.0", "alpha"),
+			newBundle("0.2.0", "0.1.0", "alpha"),
+		),
+		"skips backwards": newMetaBundle(
+			newBundle("0.1.0", "", "alpha"),
+			withSkips(newBundle("0.2.0", "0.1.0", "alpha"), "0.3.0"),
+			newBundle("0.3.0", "", "alpha"),
+		),
+		"startingCSV not in defaultChannel": withStartingCSV(newMetaBundle(
+			newBundle("0.1.0", "", "beta"),
+			newBundle("0.2.0", "0.1.0", "alpha"),
+		), "0.1.0"),
+	})
+}
+
+func TestUpgradeGraphMessages(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewUpgradeGraph)
+
+	for name, tc := range map[string]struct {
+		MetaBundle types.MetaBundle
+		Expected   []string
+	}{
+		"multiple heads and orphaned bundle": {
+			MetaBundle: newMetaBundle(
+				newBundle("0.1.0", "", "alpha"),
+				newBundle("0.2.0", "0.1.0", "alpha"),
+				newBundle("0.3.0", "", "alpha"),
+			),
+			Expected: []string{
+				`channel "alpha" has multiple heads: reference-addon.v0.2.0, reference-addon.v0.3.0.`,
+				`bundle "reference-addon.v0.1.0" in channel "alpha" is orphaned: it can't be upgraded to the channel head "reference-addon.v0.3.0".`,
+			},
+		},
+		"cycle": {
+			MetaBundle: newMetaBundle(
+				newBundle("0.1.0", "0.2.0", "alpha"),
+				newBundle("0.2.0", "0.1.0", "alpha"),
+			),
+			Expected: []string{
+				`channel "alpha" contains an upgrade cycle: reference-addon.v0.1.0 -> reference-addon.v0.2.0 -> reference-addon.v0.1.0.`,
+				`bundle "reference-addon.v0.1.0" in channel "alpha" upgrades from "reference-addon.v0.2.0" which is not an older version.`,
+			},
+		},
+		"startingCSV not reachable": {
+			MetaBundle: withStartingCSV(newMetaBundle(
+				newBundle("0.1.0", "", "alpha"),
+			), "0.0.1"),
+			Expected: []string{
+				`startingCSV "reference-addon.v0.0.1" is not reachable from the head of the defaultChannel "alpha".`,
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tester.TestSingleBundle(tc.MetaBundle)
+			require.False(t, res.IsError())
+
+			assert.Equal(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func newMetaBundle(bundles ...operator.Bundle) types.MetaBundle {
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			DefaultChannel: "alpha",
+		},
+		Bundles: bundles,
+	}
+}
+
+func newBundle(version, replaces string, channels ...string) operator.Bundle {
+	bundle := operator.Bundle{
+		Annotations: operator.Annotations{
+			PackageName: "reference-addon",
+			Channels:    channels,
+		},
+		ClusterServiceVersion: operator.ClusterServiceVersion{
+			Name: csvName(version),
+		},
+		Version: version,
+	}
+
+	if replaces != "" {
+		bundle.ClusterServiceVersion.Spec.Replaces = csvName(replaces)
+	}
+
+	return bundle
+}
+
+func withSkips(b operator.Bundle, versions ...string) operator.Bundle {
+	for _, v := range versions {
+		b.ClusterServiceVersion.Spec.Skips = append(b.ClusterServiceVersion.Spec.Skips, csvName(v))
+	}
+
+	return b
+}
+
+func withSkipRange(b operator.Bundle, skipRange string) operator.Bundle {
+	b.ClusterServiceVersion.Annotations = map[string]string{
+		operator.SkipRangeAnnotation: skipRange,
+	}
+
+	return b
+}
+
+func withStartingCSV(mb types.MetaBundle, version string) types.MetaBundle {
+	startingCSV := csvName(version)
+	mb.AddonMeta.StartingCSV = &startingCSV
+
+	return mb
+}
+
+func csvName(version string) string {
+	return "reference-addon.v" + version
+}
@@ -0,0 +1,133 @@
+package am0019
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/distribution/reference"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewRelatedImages)
+}
+
+const (
+	code = 19
+	name = "related_images"
+	desc = "Ensure the imageset's relatedImages match the images referenced by the CSV of the head bundle and are referenced by digest"
+)
+
+func NewRelatedImages(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseCapabilities(validator.CapabilityBundles),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RelatedImages{
+		Base: base,
+	}, nil
+}
+
+type RelatedImages struct {
+	*validator.Base
+}
+
+func (r *RelatedImages) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if mb.ImageSet == nil {
+		return r.Skip("addon metadata is not combined with an imageset")
+	}
+
+	bundle, ok := operator.HeadBundle(mb.Bundles...)
+	if !ok {
+		return r.Skip("no bundles found for the addon's operator")
+	}
+
+	var msgs []string
+
+	// findings for valid images are reported once even if
+	// the images are referenced in several places
+	reported := make(map[string]bool)
+
+	report := func(key, msg string) {
+		if msg == "" || reported[key] {
+			return
+		}
+
+		if key != "" {
+			reported[key] = true
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	related := make(map[string]bool)
+
+	for _, img := range mb.ImageSet.RelatedImages {
+		key, msg := checkReference(img, "relatedImages")
+		report(key, msg)
+
+		if key != "" {
+			related[key] = true
+		}
+	}
+
+	referenced := make(map[string]bool)
+
+	for _, ref := range bundle.ClusterServiceVersion.ImageReferences() {
+		key, msg := checkReference(ref.Image, ref.Source)
+		report(key, msg)
+
+		if key == "" {
+			continue
+		}
+
+		referenced[key] = true
+
+		if !related[key] {
+			report("missing:"+key, fmt.Sprintf(
+				"image %q referenced by %s is missing from relatedImages.", ref.Image, ref.Source,
+			))
+		}
+	}
+
+	for _, img := range mb.ImageSet.RelatedImages {
+		if key, _ := checkReference(img, ""); key != "" && !referenced[key] {
+			report("unused:"+key, fmt.Sprintf(
+				"relatedImages lists image %q which is not referenced by CSV %q.", img, bundle.ClusterServiceVersion.Name,
+			))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return r.Fail(msgs...)
+	}
+
+	return r.Success()
+}
+
+// checkReference parses the given image and returns its normalized
+// form. A failure message is returned if the image is invalid or is
+// not referenced by digest. The normalized form is empty if the image
+// is invalid and ignores the tags of images referenced by digest.
+func checkReference(img, source string) (string, string) {
+	named, err := reference.ParseNormalizedNamed(img)
+	if err != nil {
+		return "", fmt.Sprintf("image %q referenced by %s is invalid: %v.", img, source, err)
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		return named.Name() + "@" + digested.Digest().String(), ""
+	}
+
+	return reference.TagNameOnly(named).String(), fmt.Sprintf(
+		"image %q referenced by %s uses a mutable tag instead of a digest.", img, source,
+	)
+}
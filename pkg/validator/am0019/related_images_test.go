@@ -0,0 +1,128 @@
+package am0019
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	initImage    = "quay.io/app-sre/reference-addon-init@sha256:5b8ba80d8a4c27b9cd5dbb5d2c2e5d4c8c6d8c6d8f5ce8f8cf2fd5c3fe8f1c2a"
+	managerImage = "quay.io/app-sre/reference-addon-manager@sha256:214792459db8e6b829f5b5e315a0150304fa2242552a0dd9834272058d2074a8"
+	agentImage   = "quay.io/app-sre/reference-addon-agent@sha256:0c8b02008f2c2faeb681ae8cd454821266a794435aea4b3f7ae28c74bc2e280d"
+)
+
+func newMetaBundle(t *testing.T, csv string, relatedImages ...string) types.MetaBundle {
+	t.Helper()
+
+	loader := testutils.NewBundlerLoader(t)
+
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			OperatorName: "reference-addon",
+		},
+		ImageSet: &v1alpha1.AddonImageSetSpec{
+			RelatedImages: relatedImages,
+		},
+		Bundles: []operator.Bundle{
+			loader.LoadFromCSV(filepath.Join("test_csvs", csv)),
+		},
+	}
+}
+
+func TestRelatedImagesValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRelatedImages)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"all images listed by digest": newMetaBundle(t, "csv_valid.yaml",
+			initImage, managerImage, agentImage,
+		),
+		"tagged digest": newMetaBundle(t, "csv_valid.yaml",
+			initImage,
+			"quay.io/app-sre/reference-addon-manager:v0.1.6@sha256:214792459db8e6b829f5b5e315a0150304fa2242552a0dd9834272058d2074a8",
+			agentImage,
+		),
+	})
+}
+
+func TestRelatedImagesInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRelatedImages)
+
+	for name, tc := range map[string]struct {
+		MetaBundle types.MetaBundle
+		Expected   []string
+	}{
+		"missing related image": {
+			MetaBundle: newMetaBundle(t, "csv_valid.yaml", initImage, managerImage),
+			Expected: []string{
+				`image "` + agentImage + `" referenced by deployment "reference-addon" container "manager" env "RELATED_IMAGE_AGENT" is missing from relatedImages.`,
+			},
+		},
+		"unused related image": {
+			MetaBundle: newMetaBundle(t, "csv_valid.yaml",
+				initImage, managerImage, agentImage,
+				"quay.io/app-sre/unused@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
+			),
+			Expected: []string{
+				`relatedImages lists image "quay.io/app-sre/unused@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d" which is not referenced by CSV "reference-addon.0.1.6".`,
+			},
+		},
+		"unused related image listed twice": {
+			MetaBundle: newMetaBundle(t, "csv_valid.yaml",
+				initImage, managerImage, agentImage,
+				"quay.io/app-sre/unused@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
+				"quay.io/app-sre/unused:v1@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
+			),
+			Expected: []string{
+				`relatedImages lists image "quay.io/app-sre/unused@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d" which is not referenced by CSV "reference-addon.0.1.6".`,
+			},
+		},
+		"image referenced by tag": {
+			MetaBundle: newMetaBundle(t, "csv_tagged_image.yaml",
+				initImage, "quay.io/app-sre/reference-addon-manager:latest", agentImage,
+			),
+			Expected: []string{
+				`image "quay.io/app-sre/reference-addon-manager:latest" referenced by relatedImages uses a mutable tag instead of a digest.`,
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tester.TestSingleBundle(tc.MetaBundle)
+			assert.False(t, res.IsSuccess())
+			assert.ElementsMatch(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func TestRelatedImagesSkipped(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRelatedImages)
+	tester.TestSkippedBundles(map[string]types.MetaBundle{
+		"no imageset": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+		},
+		"no bundles": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+			ImageSet: &v1alpha1.AddonImageSetSpec{
+				RelatedImages: []string{},
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package am0020
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	imageparser "github.com/novln/docker-parser"
+)
+
+func init() {
+	validator.Register(NewImageReferences)
+}
+
+const (
+	code = 20
+	name = "image_references"
+	desc = "Ensure every image referenced by the addon metadata, its imageset and the CSV of its head bundle exists"
+)
+
+func NewImageReferences(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseCapabilities(
+			validator.CapabilityNetwork,
+			validator.CapabilityRegistry,
+			validator.CapabilityBundles,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageReferences{
+		Base:     base,
+		registry: deps.RegistryClient,
+	}, nil
+}
+
+type ImageReferences struct {
+	*validator.Base
+	registry validator.RegistryClient
+}
+
+func (i *ImageReferences) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var (
+		msgs []string
+		errs []error
+	)
+
+	for _, ref := range collectReferences(mb) {
+		source := strings.Join(ref.Sources, ", ")
+
+		parsed, err := imageparser.Parse(ref.Image)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("image %q referenced by %s is not a valid image reference.", ref.Image, source))
+
+			continue
+		}
+
+		ok, err := i.registry.HasReference(ctx, parsed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("checking image %q: %w", ref.Image, err))
+
+			continue
+		}
+
+		if !ok {
+			msgs = append(msgs, fmt.Sprintf("image %q referenced by %s does not exist.", ref.Image, source))
+		}
+	}
+
+	if len(errs) > 0 {
+		return i.Errors(errs...)
+	}
+
+	if len(msgs) > 0 {
+		return i.Fail(msgs...)
+	}
+
+	return i.Success()
+}
+
+// collectReferences returns the images referenced by the addon metadata,
+// the imageset it is combined with and the CSV of the head bundle.
+func collectReferences(mb types.MetaBundle) []operator.ImageSources {
+	var refs []operator.ImageReference
+
+	meta := mb.AddonMeta
+
+	if meta.IndexImage != nil {
+		refs = append(refs, operator.ImageReference{Image: *meta.IndexImage, Source: "indexImage"})
+	}
+
+	if mb.ImageSet != nil {
+		refs = append(refs, operator.ImageReference{Image: mb.ImageSet.PackageImage, Source: "packageImage"})
+
+		for _, img := range mb.ImageSet.RelatedImages {
+			refs = append(refs, operator.ImageReference{Image: img, Source: "relatedImages"})
+		}
+	}
+
+	if meta.AdditionalCatalogSources != nil {
+		for _, src := range *meta.AdditionalCatalogSources {
+			refs = append(refs, operator.ImageReference{
+				Image:  src.Image,
+				Source: fmt.Sprintf("additionalCatalogSource %q", src.Name),
+			})
+		}
+	}
+
+	if bundle, ok := operator.HeadBundle(mb.Bundles...); ok {
+		refs = append(refs, bundle.ClusterServiceVersion.ImageReferences()...)
+	}
+
+	return operator.GroupImageReferences(refs...)
+}
@@ -0,0 +1,158 @@
+package am0020
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	imageparser "github.com/novln/docker-parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexImage   = "quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d"
+	packageImage = "quay.io/osd-addons/reference-addon-package@sha256:a7ed4d4b10e8d4a0b2fa2d1b9e20fd8eb5c0b3f2a0a3d5c6c5d2e2b0b0f1e4a3"
+	catalogImage = "quay.io/osd-addons/reference-addon-extra:v1.0.0"
+	initImage    = "quay.io/app-sre/reference-addon-init@sha256:5b8ba80d8a4c27b9cd5dbb5d2c2e5d4c8c6d8c6d8f5ce8f8cf2fd5c3fe8f1c2a"
+	managerImage = "quay.io/app-sre/reference-addon-manager@sha256:214792459db8e6b829f5b5e315a0150304fa2242552a0dd9834272058d2074a8"
+	agentImage   = "quay.io/app-sre/reference-addon-agent@sha256:0c8b02008f2c2faeb681ae8cd454821266a794435aea4b3f7ae28c74bc2e280d"
+)
+
+func newMetaBundle(t *testing.T, relatedImages ...string) types.MetaBundle {
+	t.Helper()
+
+	loader := testutils.NewBundlerLoader(t)
+
+	index := indexImage
+
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			OperatorName: "reference-addon",
+			IndexImage:   &index,
+			AdditionalCatalogSources: &[]mtsrev1.AdditionalCatalogSource{
+				{Name: "extra", Image: catalogImage},
+			},
+		},
+		ImageSet: &v1alpha1.AddonImageSetSpec{
+			PackageImage:  packageImage,
+			RelatedImages: relatedImages,
+		},
+		Bundles: []operator.Bundle{
+			loader.LoadFromCSV(filepath.Join("test_csvs", "csv.yaml")),
+		},
+	}
+}
+
+// newRegistry returns a mocked registry client in which only
+// the given images exist.
+func newRegistry(t *testing.T, images ...string) *testutils.MockRegistryClient {
+	t.Helper()
+
+	registry := testutils.NewMockRegistryClient()
+
+	for _, img := range images {
+		registry.On("HasReference", mock.Anything, getRef(t, img)).Return(true, nil)
+	}
+
+	registry.On("HasReference", mock.Anything, mock.Anything).Return(false, nil)
+
+	return registry
+}
+
+func getRef(t *testing.T, img string) *imageparser.Reference {
+	t.Helper()
+
+	ref, err := imageparser.Parse(img)
+	require.NoError(t, err)
+
+	return ref
+}
+
+func TestImageReferencesValid(t *testing.T) {
+	t.Parallel()
+
+	registry := newRegistry(t,
+		indexImage, packageImage, catalogImage, initImage, managerImage, agentImage,
+	)
+
+	tester := testutils.NewValidatorTester(t,
+		NewImageReferences,
+		testutils.ValidatorTesterRegistryClient(registry),
+	)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"all images exist": newMetaBundle(t, initImage, managerImage, agentImage),
+		"no bundles": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+		},
+	})
+}
+
+func TestImageReferencesInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Existing   []string
+		MetaBundle types.MetaBundle
+		Expected   []string
+	}{
+		"missing images": {
+			Existing:   []string{indexImage, initImage, agentImage},
+			MetaBundle: newMetaBundle(t, initImage, managerImage, agentImage),
+			Expected: []string{
+				`image "` + packageImage + `" referenced by packageImage does not exist.`,
+				`image "` + catalogImage + `" referenced by additionalCatalogSource "extra" does not exist.`,
+				`image "` + managerImage + `" referenced by relatedImages, deployment "reference-addon" container "manager", CSV relatedImage "manager" does not exist.`,
+			},
+		},
+		"invalid image": {
+			Existing: []string{
+				indexImage, packageImage, catalogImage, initImage, managerImage, agentImage,
+			},
+			MetaBundle: newMetaBundle(t, "quay.io/app-sre/Invalid:v1.0.0"),
+			Expected: []string{
+				`image "quay.io/app-sre/Invalid:v1.0.0" referenced by relatedImages is not a valid image reference.`,
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tester := testutils.NewValidatorTester(t,
+				NewImageReferences,
+				testutils.ValidatorTesterRegistryClient(newRegistry(t, tc.Existing...)),
+			)
+
+			res := tester.TestSingleBundle(tc.MetaBundle)
+			assert.False(t, res.IsSuccess())
+			assert.ElementsMatch(t, tc.Expected, res.FailureMsgs)
+		})
+	}
+}
+
+func TestImageReferencesRetryableError(t *testing.T) {
+	t.Parallel()
+
+	registry := testutils.NewMockRegistryClient()
+	registry.
+		On("HasReference", mock.Anything, mock.Anything).
+		Return(false, validator.RegistryResponseError(503))
+
+	tester := testutils.NewValidatorTester(t,
+		NewImageReferences,
+		testutils.ValidatorTesterRegistryClient(registry),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle(t))
+	assert.True(t, res.IsRetryableError())
+}
@@ -0,0 +1,133 @@
+package am0021
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	imageparser "github.com/novln/docker-parser"
+)
+
+func init() {
+	validator.Register(NewArchitectures)
+}
+
+const (
+	code = 21
+	name = "architectures"
+	desc = "Ensure the images of the head bundle and the related images are published for every architecture declared by the CSV"
+)
+
+func NewArchitectures(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseCapabilities(
+			validator.CapabilityNetwork,
+			validator.CapabilityRegistry,
+			validator.CapabilityBundles,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Architectures{
+		Base:     base,
+		registry: deps.RegistryClient,
+	}, nil
+}
+
+type Architectures struct {
+	*validator.Base
+	registry validator.RegistryClient
+}
+
+func (a *Architectures) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	bundle, ok := operator.HeadBundle(mb.Bundles...)
+	if !ok {
+		return a.Skip("no bundles found for the addon's operator")
+	}
+
+	csv := bundle.ClusterServiceVersion
+	declared := csv.Architectures()
+
+	var (
+		msgs []string
+		errs []error
+	)
+
+	for _, ref := range collectReferences(csv, mb.ImageSet) {
+		// invalid and missing images are reported by other validators
+		parsed, err := imageparser.Parse(ref.Image)
+		if err != nil {
+			continue
+		}
+
+		platforms, err := a.registry.Platforms(ctx, parsed)
+		if errors.Is(err, validator.ErrImageNotFound) {
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("retrieving platforms of image %q: %w", ref.Image, err))
+
+			continue
+		}
+
+		if missing := missingArchitectures(declared, platforms); len(missing) > 0 {
+			msgs = append(msgs, fmt.Sprintf(
+				"image %q referenced by %s is not published for architecture(s) %s declared by CSV %q.",
+				ref.Image, strings.Join(ref.Sources, ", "), strings.Join(missing, ", "), csv.Name,
+			))
+		}
+	}
+
+	if len(errs) > 0 {
+		return a.Errors(errs...)
+	}
+
+	if len(msgs) > 0 {
+		return a.Fail(msgs...)
+	}
+
+	return a.Success()
+}
+
+// missingArchitectures returns the declared architectures
+// none of the given platforms are built for.
+func missingArchitectures(declared []string, platforms []validator.Platform) []string {
+	published := make(map[string]bool, len(platforms))
+
+	for _, p := range platforms {
+		published[p.Architecture] = true
+	}
+
+	var missing []string
+
+	for _, arch := range declared {
+		if !published[arch] {
+			missing = append(missing, arch)
+		}
+	}
+
+	return missing
+}
+
+// collectReferences returns the images referenced by the given
+// CSV and the related images of the given imageset.
+func collectReferences(csv operator.ClusterServiceVersion, imageSet *v1alpha1.AddonImageSetSpec) []operator.ImageSources {
+	refs := csv.ImageReferences()
+
+	if imageSet != nil {
+		for _, img := range imageSet.RelatedImages {
+			refs = append(refs, operator.ImageReference{Image: img, Source: "relatedImages"})
+		}
+	}
+
+	return operator.GroupImageReferences(refs...)
+}
@@ -0,0 +1,104 @@
+package am0021
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	linuxAMD64 = validator.Platform{OS: "linux", Architecture: "amd64"}
+	linuxARM64 = validator.Platform{OS: "linux", Architecture: "arm64"}
+)
+
+// newMetaBundle loads the test CSV referencing images of the
+// given registry.
+func newMetaBundle(t *testing.T, reg *testutils.FakeRegistry, relatedImages ...string) types.MetaBundle {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("test_csvs", "csv.yaml"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "csv.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(strings.ReplaceAll(string(data), "REGISTRY", reg.Host())), 0o600))
+
+	loader := testutils.NewBundlerLoader(t)
+
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			OperatorName: "reference-addon",
+		},
+		ImageSet: &v1alpha1.AddonImageSetSpec{
+			RelatedImages: relatedImages,
+		},
+		Bundles: []operator.Bundle{loader.LoadFromCSV(path)},
+	}
+}
+
+func TestArchitecturesValid(t *testing.T) {
+	t.Parallel()
+
+	reg := testutils.NewFakeRegistry(t)
+	reg.PushIndex("app-sre/reference-addon-manager", "v0.1.6", linuxAMD64, linuxARM64)
+	reg.PushIndex("app-sre/reference-addon-agent", "v0.1.6", linuxAMD64, linuxARM64)
+	reg.PushIndex("app-sre/reference-addon-extra", "v0.1.6", linuxAMD64, linuxARM64)
+
+	tester := testutils.NewValidatorTester(t,
+		NewArchitectures,
+		testutils.ValidatorTesterRegistryClient(reg.Client()),
+	)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"all architectures published": newMetaBundle(t, reg,
+			reg.Host()+"/app-sre/reference-addon-extra:v0.1.6",
+		),
+		"missing images are ignored": newMetaBundle(t, reg,
+			reg.Host()+"/app-sre/reference-addon-missing:v0.1.6",
+		),
+	})
+}
+
+func TestArchitecturesInvalid(t *testing.T) {
+	t.Parallel()
+
+	reg := testutils.NewFakeRegistry(t)
+	reg.PushIndex("app-sre/reference-addon-manager", "v0.1.6", linuxAMD64, linuxARM64)
+	reg.PushImage("app-sre/reference-addon-agent", "v0.1.6", linuxAMD64)
+	reg.PushIndex("app-sre/reference-addon-extra", "v0.1.6", linuxARM64)
+
+	tester := testutils.NewValidatorTester(t,
+		NewArchitectures,
+		testutils.ValidatorTesterRegistryClient(reg.Client()),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle(t, reg,
+		reg.Host()+"/app-sre/reference-addon-agent:v0.1.6",
+		reg.Host()+"/app-sre/reference-addon-extra:v0.1.6",
+	))
+	assert.False(t, res.IsSuccess())
+	assert.ElementsMatch(t, []string{
+		`image "` + reg.Host() + `/app-sre/reference-addon-agent:v0.1.6" referenced by deployment "reference-addon" container "manager" env "RELATED_IMAGE_AGENT", relatedImages is not published for architecture(s) arm64 declared by CSV "reference-addon.0.1.6".`,
+		`image "` + reg.Host() + `/app-sre/reference-addon-extra:v0.1.6" referenced by relatedImages is not published for architecture(s) amd64 declared by CSV "reference-addon.0.1.6".`,
+	}, res.FailureMsgs)
+}
+
+func TestArchitecturesSkipped(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewArchitectures)
+	tester.TestSkippedBundles(map[string]types.MetaBundle{
+		"no bundles": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				OperatorName: "reference-addon",
+			},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability is a resource a Validator requires in order to run.
+type Capability string
+
+const (
+	// CapabilityNetwork is required by validators which
+	// reach out to any service over the network.
+	CapabilityNetwork Capability = "network"
+	// CapabilityOCM is required by validators which query
+	// the OCM API.
+	CapabilityOCM Capability = "ocm"
+	// CapabilityRegistry is required by validators which
+	// query container image registries.
+	CapabilityRegistry Capability = "registry"
+	// CapabilityBundles is required by validators which
+	// inspect the extracted bundles of an addon.
+	CapabilityBundles Capability = "bundles"
+)
+
+// Capabilities returns all known capabilities.
+func Capabilities() []Capability {
+	return append(NetworkCapabilities(), CapabilityBundles)
+}
+
+// ParseCapability converts the given string to a Capability value.
+// An error is returned if the string does not name a known capability.
+func ParseCapability(maybeCapability string) (Capability, error) {
+	for _, c := range Capabilities() {
+		if strings.EqualFold(string(c), maybeCapability) {
+			return c, nil
+		}
+	}
+
+	return Capability(""), fmt.Errorf("unable to parse capability from '%s'", maybeCapability)
+}
+
+// NetworkCapabilities returns the capabilities which
+// can't be provided without network access.
+func NetworkCapabilities() []Capability {
+	return []Capability{
+		CapabilityNetwork,
+		CapabilityOCM,
+		CapabilityRegistry,
+	}
+}
+
+// RequiresAnyCapability returns a Filter matching the validators
+// which require at least one of the given capabilities.
+func RequiresAnyCapability(caps ...Capability) Filter {
+	return func(v Validator) bool {
+		for _, required := range v.Capabilities() {
+			for _, c := range caps {
+				if required == c {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresAnyCapability(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Capabilities []Capability
+		Filter       Filter
+		Expected     bool
+	}{
+		"no capabilities": {
+			Filter:   RequiresAnyCapability(NetworkCapabilities()...),
+			Expected: false,
+		},
+		"matching capability": {
+			Capabilities: []Capability{CapabilityNetwork, CapabilityOCM},
+			Filter:       RequiresAnyCapability(CapabilityOCM),
+			Expected:     true,
+		},
+		"other capability": {
+			Capabilities: []Capability{CapabilityBundles},
+			Filter:       RequiresAnyCapability(NetworkCapabilities()...),
+			Expected:     false,
+		},
+		"negated": {
+			Capabilities: []Capability{CapabilityRegistry},
+			Filter:       Not(RequiresAnyCapability(CapabilityRegistry)),
+			Expected:     false,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			base, err := NewBase(1, BaseCapabilities(tc.Capabilities...))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, tc.Filter(&ValidatorMock{Base: base}))
+		})
+	}
+}
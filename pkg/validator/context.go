@@ -0,0 +1,40 @@
+package validator
+
+import "context"
+
+type validatorKey struct{}
+
+// ContextWithValidator returns a copy of the given context
+// carrying the Validator which is run with it.
+func ContextWithValidator(ctx context.Context, v Validator) context.Context {
+	return context.WithValue(ctx, validatorKey{}, v)
+}
+
+// ValidatorFromContext returns the Validator which is run with
+// the given context if any. Middleware may use it to configure
+// itself per Validator.
+func ValidatorFromContext(ctx context.Context) (Validator, bool) {
+	v, ok := ctx.Value(validatorKey{}).(Validator)
+
+	return v, ok
+}
+
+// resultFromContext returns a Result populated with the
+// details of the Validator run with the given context.
+func resultFromContext(ctx context.Context) Result {
+	v, ok := ValidatorFromContext(ctx)
+	if !ok {
+		return Result{}
+	}
+
+	return resultFor(v)
+}
+
+func resultFor(v Validator) Result {
+	return Result{
+		Code:        v.Code(),
+		Name:        v.Name(),
+		Description: v.Description(),
+		Severity:    v.Severity(),
+	}
+}
@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IsServerSideError determines if the given error or any error it
+// wraps reports being caused by a server-side issue.
+func IsServerSideError(err error) bool {
+	var serverSide interface{ ServerSide() bool }
+
+	return errors.As(err, &serverSide) && serverSide.ServerSide()
+}
+
+// RetryAfterError wraps errors of requests which a server asked to
+// repeat after a delay e.g. HTTP 429 responses with a 'Retry-After'
+// header.
+type RetryAfterError struct {
+	Err error
+	// After is the delay requested by the server. A value of
+	// '0' means no specific delay was requested.
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	if e.After <= 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%v: retry after %s", e.Err, e.After)
+}
+
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// newResponseError wraps the given error in a RetryAfterError if the
+// given HTTP status code asks clients to slow down or retry later.
+func newResponseError(err error, code int, header http.Header) error {
+	if code != http.StatusTooManyRequests && code != http.StatusServiceUnavailable {
+		return err
+	}
+
+	after, _ := ParseRetryAfter(header.Get("Retry-After"), time.Now())
+
+	return &RetryAfterError{Err: err, After: after}
+}
+
+// ParseRetryAfter parses the value of a 'Retry-After' header given
+// either in seconds or as an HTTP date relative to the given time.
+// 'ok' is false if the value can't be parsed.
+func ParseRetryAfter(val string, now time.Time) (after time.Duration, ok bool) {
+	if val == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(val); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	date, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+
+	if after := date.Sub(now); after > 0 {
+		return after, true
+	}
+
+	return 0, true
+}
+
+// RegistryResponseError is used to wrap HTTP error (400 - 599)
+// response codes which are returned from a request to a registry.
+type RegistryResponseError int
+
+func (e RegistryResponseError) Error() string {
+	return fmt.Sprintf("registry responded with code %d", e)
+}
+
+func (e RegistryResponseError) ServerSide() bool {
+	code := int(e)
+
+	return code >= 500 && code < 600
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		Value      string
+		Expected   time.Duration
+		ExpectedOK bool
+	}{
+		"empty": {
+			Value: "",
+		},
+		"seconds": {
+			Value:      "120",
+			Expected:   2 * time.Minute,
+			ExpectedOK: true,
+		},
+		"negative seconds": {
+			Value: "-1",
+		},
+		"http date": {
+			Value:      now.Add(30 * time.Second).Format(http.TimeFormat),
+			Expected:   30 * time.Second,
+			ExpectedOK: true,
+		},
+		"http date in the past": {
+			Value:      now.Add(-time.Minute).Format(http.TimeFormat),
+			Expected:   0,
+			ExpectedOK: true,
+		},
+		"invalid": {
+			Value: "soon",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			after, ok := ParseRetryAfter(tc.Value, now)
+
+			assert.Equal(t, tc.ExpectedOK, ok)
+			assert.Equal(t, tc.Expected, after)
+		})
+	}
+}
+
+func TestIsServerSideError(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Err      error
+		Expected bool
+	}{
+		"ocm server-side": {
+			Err:      OCMResponseError(500),
+			Expected: true,
+		},
+		"wrapped registry server-side": {
+			Err:      fmt.Errorf("sending HTTP request: %w", RegistryResponseError(503)),
+			Expected: true,
+		},
+		"retry after server-side": {
+			Err:      &RetryAfterError{Err: RegistryResponseError(503)},
+			Expected: true,
+		},
+		"client-side": {
+			Err:      RegistryResponseError(429),
+			Expected: false,
+		},
+		"other": {
+			Err:      errors.New("boom"),
+			Expected: false,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, IsServerSideError(tc.Err))
+		})
+	}
+}
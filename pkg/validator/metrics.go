@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewMetricsMiddleware returns middleware which records the duration,
+// outcome and retries of validator runs per Code. The middleware is a
+// prometheus.Collector and must be registered with a registry to
+// expose the metrics. It should wrap any retry middleware so that
+// durations include retries and retries can be counted.
+func NewMetricsMiddleware() *MetricsMiddleware {
+	labels := []string{"code", "name"}
+
+	return &MetricsMiddleware{
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "validator_duration_seconds",
+				Help:      "Duration of validator runs including retries.",
+				Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+			},
+			labels,
+		),
+		results: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "validator_results_total",
+				Help:      "Number of validator runs by outcome.",
+			},
+			append(labels, "outcome"),
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "validator_retries_total",
+				Help:      "Number of retried validator runs.",
+			},
+			labels,
+		),
+	}
+}
+
+const metricsNamespace = "addon_metadata"
+
+type MetricsMiddleware struct {
+	duration *prometheus.HistogramVec
+	results  *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+func (m *MetricsMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		start := time.Now()
+
+		res := run(ctx, mb)
+
+		code, name := res.Code.String(), res.Name
+
+		if v, ok := ValidatorFromContext(ctx); ok {
+			code, name = v.Code().String(), v.Name()
+		}
+
+		m.duration.WithLabelValues(code, name).Observe(time.Since(start).Seconds())
+		m.results.WithLabelValues(code, name, res.Outcome()).Inc()
+
+		if res.Attempts > 1 {
+			m.retries.WithLabelValues(code, name).Add(float64(res.Attempts - 1))
+		}
+
+		return res
+	}
+}
+
+func (m *MetricsMiddleware) Describe(ch chan<- *prometheus.Desc) {
+	m.duration.Describe(ch)
+	m.results.Describe(ch)
+	m.retries.Describe(ch)
+}
+
+func (m *MetricsMiddleware) Collect(ch chan<- prometheus.Metric) {
+	m.duration.Collect(ch)
+	m.results.Collect(ch)
+	m.retries.Collect(ch)
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+
+	metrics := NewMetricsMiddleware()
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(metrics))
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(1, "flaky", "fails once", func(context.Context, types.MetaBundle) Result {
+				attempts++
+
+				if attempts == 1 {
+					return Result{retryable: true}
+				}
+
+				return Result{success: true}
+			}),
+			NewValidatorMock(2, "failing", "always fails", func(context.Context, types.MetaBundle) Result {
+				return Result{FailureMsgs: []string{"failed"}}
+			}),
+		},
+		WithMiddleware{
+			NewRetryMiddleware(WithDelay(0)),
+			metrics,
+		},
+	)
+	require.NoError(t, err)
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		assert.Positive(t, res.Duration)
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.results.WithLabelValues("AM0001", "flaky", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.results.WithLabelValues("AM0002", "failing", "failure")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.retries.WithLabelValues("AM0001", "flaky")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.duration))
+
+	count, err := testutil.GatherAndCount(reg,
+		"addon_metadata_validator_duration_seconds",
+		"addon_metadata_validator_results_total",
+		"addon_metadata_validator_retries_total",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
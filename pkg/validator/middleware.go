@@ -2,6 +2,10 @@ package validator
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"runtime/debug"
 	"time"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -13,9 +17,17 @@ type Middleware interface {
 
 type RunFunc func(context.Context, types.MetaBundle) Result
 
+// NewRetryMiddleware returns middleware which retries validators
+// reporting retryable results with an exponentially growing and
+// randomized delay between attempts. Retries stop once the maximum
+// number of attempts or the maximum elapsed time is reached or the
+// context is cancelled in which case the last result is returned.
 func NewRetryMiddleware(opts ...RetryMiddlewareOption) *RetryMiddleware {
 	cfg := RetryMiddlewareConfig{
-		Delay: 2 * time.Second,
+		Delay:          2 * time.Second,
+		MaxDelay:       30 * time.Second,
+		Jitter:         0.2,
+		MaxElapsedTime: 5 * time.Minute,
 	}
 
 	cfg.Option(opts...)
@@ -32,24 +44,124 @@ type RetryMiddleware struct {
 
 func (r *RetryMiddleware) Wrap(run RunFunc) RunFunc {
 	return func(ctx context.Context, mb types.MetaBundle) Result {
-		var res Result
+		start := time.Now()
+		delay := r.cfg.Delay
 
-		for attempts := 0; attempts < r.cfg.MaxAttempts; attempts++ {
-			res = run(ctx, mb)
-			if !res.IsRetryableError() {
+		for attempt := 1; ; attempt++ {
+			res := run(ctx, mb)
+
+			res.Attempts = attempt
+
+			retry, wait := r.cfg.Classifier(res)
+			if !retry || attempt >= r.cfg.MaxAttempts {
+				return res
+			}
+
+			// delays requested by the server take precedence
+			if wait <= 0 {
+				wait = r.jitter(delay)
+			}
+
+			if r.cfg.MaxElapsedTime > 0 && time.Since(start)+wait > r.cfg.MaxElapsedTime {
 				return res
 			}
 
-			time.Sleep(time.Duration(r.cfg.Delay))
+			timer := time.NewTimer(wait)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return res
+			case <-timer.C:
+			}
+
+			delay = r.next(delay)
 		}
+	}
+}
 
-		return res
+// jitter randomizes the given delay by up to the
+// configured fraction in either direction.
+func (r *RetryMiddleware) jitter(delay time.Duration) time.Duration {
+	if r.cfg.Jitter <= 0 {
+		return delay
 	}
+
+	delta := r.cfg.Jitter * float64(delay)
+
+	return time.Duration(float64(delay) - delta + rand.Float64()*2*delta)
+}
+
+// next returns the delay following the given delay
+// limited by the configured maximum delay.
+func (r *RetryMiddleware) next(delay time.Duration) time.Duration {
+	next := time.Duration(float64(delay) * r.cfg.Multiplier)
+
+	if r.cfg.MaxDelay > 0 && next > r.cfg.MaxDelay {
+		return r.cfg.MaxDelay
+	}
+
+	return next
+}
+
+// RetryClassifier decides whether the given Result should be retried.
+// A positive delay overrides the backoff delay before the next attempt.
+type RetryClassifier func(Result) (retry bool, delay time.Duration)
+
+// DefaultRetryClassifier retries results reported as retryable by
+// validators and errors which were caused server-side. Errors which
+// request a delay through a RetryAfterError are retried after that
+// delay.
+func DefaultRetryClassifier(res Result) (bool, time.Duration) {
+	if res.IsRetryableError() {
+		return true, retryAfter(res.Error)
+	}
+
+	if res.Error == nil {
+		return false, 0
+	}
+
+	var after *RetryAfterError
+
+	if errors.As(res.Error, &after) {
+		return true, after.After
+	}
+
+	return IsServerSideError(res.Error), 0
+}
+
+func retryAfter(err error) time.Duration {
+	var after *RetryAfterError
+
+	if errors.As(err, &after) {
+		return after.After
+	}
+
+	return 0
 }
 
 type RetryMiddlewareConfig struct {
+	// MaxAttempts is the maximum number of runs including
+	// the first one.
 	MaxAttempts int
-	Delay       time.Duration
+	// Delay is the delay before the first retry.
+	Delay time.Duration
+	// MaxDelay limits the delay between attempts. A
+	// value of '0' disables the limit.
+	MaxDelay time.Duration
+	// Multiplier is the factor by which the delay grows
+	// after each attempt.
+	Multiplier float64
+	// Jitter is the fraction by which delays are randomized
+	// in either direction. A value of '0' disables jitter.
+	Jitter float64
+	// MaxElapsedTime stops retries which would exceed it
+	// including the delay before the next attempt. A value
+	// of '0' disables the limit.
+	MaxElapsedTime time.Duration
+	// Classifier decides which results are retried.
+	Classifier RetryClassifier
 }
 
 func (c *RetryMiddlewareConfig) Option(opts ...RetryMiddlewareOption) {
@@ -62,6 +174,14 @@ func (c *RetryMiddlewareConfig) Default() {
 	if c.MaxAttempts == 0 {
 		c.MaxAttempts = 5
 	}
+
+	if c.Multiplier == 0 {
+		c.Multiplier = 2
+	}
+
+	if c.Classifier == nil {
+		c.Classifier = DefaultRetryClassifier
+	}
 }
 
 type RetryMiddlewareOption interface {
@@ -79,3 +199,164 @@ type WithDelay time.Duration
 func (d WithDelay) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
 	c.Delay = time.Duration(d)
 }
+
+type WithMaxDelay time.Duration
+
+func (d WithMaxDelay) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.MaxDelay = time.Duration(d)
+}
+
+type WithMultiplier float64
+
+func (m WithMultiplier) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.Multiplier = float64(m)
+}
+
+type WithJitter float64
+
+func (j WithJitter) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.Jitter = float64(j)
+}
+
+type WithMaxElapsedTime time.Duration
+
+func (t WithMaxElapsedTime) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.MaxElapsedTime = time.Duration(t)
+}
+
+type WithRetryClassifier RetryClassifier
+
+func (rc WithRetryClassifier) ConfigureRetryMiddleware(c *RetryMiddlewareConfig) {
+	c.Classifier = RetryClassifier(rc)
+}
+
+var (
+	ErrValidatorPanicked = errors.New("validator panicked")
+	ErrValidatorTimedOut = errors.New("validator timed out")
+)
+
+// NewRecoverMiddleware returns middleware which converts panics of
+// the wrapped validators into error results including the stack
+// trace of the panic.
+func NewRecoverMiddleware() *RecoverMiddleware {
+	return &RecoverMiddleware{}
+}
+
+type RecoverMiddleware struct{}
+
+func (r *RecoverMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) (res Result) {
+		defer func() {
+			if p := recover(); p != nil {
+				res = resultFromContext(ctx)
+				res.Error = fmt.Errorf("%w: %v\n%s", ErrValidatorPanicked, p, debug.Stack())
+			}
+		}()
+
+		return run(ctx, mb)
+	}
+}
+
+// NewTimeoutMiddleware returns middleware which enforces a deadline
+// on the wrapped validators. Validators exceeding their deadline
+// report an error result and are left to finish in the background.
+// Panics of validators are recovered as the wrapped validators run
+// in a separate goroutine.
+func NewTimeoutMiddleware(opts ...TimeoutMiddlewareOption) *TimeoutMiddleware {
+	var cfg TimeoutMiddlewareConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &TimeoutMiddleware{
+		cfg: cfg,
+	}
+}
+
+type TimeoutMiddleware struct {
+	cfg TimeoutMiddlewareConfig
+}
+
+func (t *TimeoutMiddleware) Wrap(run RunFunc) RunFunc {
+	run = NewRecoverMiddleware().Wrap(run)
+
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		timeout := t.timeout(ctx)
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resCh := make(chan Result, 1)
+
+		go func() { resCh <- run(ctx, mb) }()
+
+		select {
+		case res := <-resCh:
+			return res
+		case <-ctx.Done():
+			res := resultFromContext(ctx)
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				res.Error = fmt.Errorf("%w after %s", ErrValidatorTimedOut, timeout)
+			} else {
+				res.Error = ctx.Err()
+			}
+
+			return res
+		}
+	}
+}
+
+// timeout returns the deadline of the validator
+// run with the given context.
+func (t *TimeoutMiddleware) timeout(ctx context.Context) time.Duration {
+	if v, ok := ValidatorFromContext(ctx); ok {
+		if timeout, ok := t.cfg.Timeouts[v.Code()]; ok && timeout > 0 {
+			return timeout
+		}
+	}
+
+	return t.cfg.DefaultTimeout
+}
+
+type TimeoutMiddlewareConfig struct {
+	// DefaultTimeout is the deadline of validators
+	// without a specific timeout.
+	DefaultTimeout time.Duration
+	// Timeouts overrides the deadline of validators by code.
+	Timeouts map[Code]time.Duration
+}
+
+func (c *TimeoutMiddlewareConfig) Option(opts ...TimeoutMiddlewareOption) {
+	for _, opt := range opts {
+		opt.ConfigureTimeoutMiddleware(c)
+	}
+}
+
+func (c *TimeoutMiddlewareConfig) Default() {
+	if c.DefaultTimeout == 0 {
+		c.DefaultTimeout = 2 * time.Minute
+	}
+}
+
+type TimeoutMiddlewareOption interface {
+	ConfigureTimeoutMiddleware(*TimeoutMiddlewareConfig)
+}
+
+type WithDefaultTimeout time.Duration
+
+func (d WithDefaultTimeout) ConfigureTimeoutMiddleware(c *TimeoutMiddlewareConfig) {
+	c.DefaultTimeout = time.Duration(d)
+}
+
+type WithTimeouts map[Code]time.Duration
+
+func (t WithTimeouts) ConfigureTimeoutMiddleware(c *TimeoutMiddlewareConfig) {
+	if c.Timeouts == nil {
+		c.Timeouts = make(map[Code]time.Duration, len(t))
+	}
+
+	for code, timeout := range t {
+		c.Timeouts[code] = timeout
+	}
+}
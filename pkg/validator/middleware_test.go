@@ -0,0 +1,279 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverMiddleware(t *testing.T) {
+	t.Parallel()
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(1, "panicking", "panics", func(context.Context, types.MetaBundle) Result {
+				var mb *types.MetaBundle
+
+				return Result{Name: mb.AddonMeta.ID}
+			}),
+		},
+		WithMiddleware{NewRecoverMiddleware()},
+	)
+	require.NoError(t, err)
+
+	res := <-runner.Run(context.Background(), types.MetaBundle{})
+
+	require.True(t, res.IsError())
+	assert.ErrorIs(t, res.Error, ErrValidatorPanicked)
+	assert.Contains(t, res.Error.Error(), "nil pointer dereference")
+	assert.Contains(t, res.Error.Error(), "middleware_test.go")
+	assert.Equal(t, Code(1), res.Code)
+	assert.Equal(t, "panicking", res.Name)
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	hang := func(ctx context.Context, _ types.MetaBundle) Result {
+		<-ctx.Done()
+
+		return Result{}
+	}
+
+	for name, tc := range map[string]struct {
+		Options       []TimeoutMiddlewareOption
+		Run           RunFunc
+		ExpectedError error
+	}{
+		"completes in time": {
+			Options: []TimeoutMiddlewareOption{
+				WithDefaultTimeout(time.Minute),
+			},
+			Run: func(context.Context, types.MetaBundle) Result {
+				return Result{success: true}
+			},
+		},
+		"default timeout": {
+			Options: []TimeoutMiddlewareOption{
+				WithDefaultTimeout(10 * time.Millisecond),
+			},
+			Run:           hang,
+			ExpectedError: ErrValidatorTimedOut,
+		},
+		"timeout by code": {
+			Options: []TimeoutMiddlewareOption{
+				WithDefaultTimeout(time.Hour),
+				WithTimeouts{1: 10 * time.Millisecond},
+			},
+			Run:           hang,
+			ExpectedError: ErrValidatorTimedOut,
+		},
+		"timeout of other code": {
+			Options: []TimeoutMiddlewareOption{
+				WithDefaultTimeout(10 * time.Millisecond),
+				WithTimeouts{2: time.Hour},
+			},
+			Run:           hang,
+			ExpectedError: ErrValidatorTimedOut,
+		},
+		"panic": {
+			Run: func(context.Context, types.MetaBundle) Result {
+				panic("boom")
+			},
+			ExpectedError: ErrValidatorPanicked,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner, err := NewRunner(
+				WithInitializers{
+					NewValidatorMock(1, "mock", "mock", tc.Run),
+				},
+				WithMiddleware{NewTimeoutMiddleware(tc.Options...)},
+			)
+			require.NoError(t, err)
+
+			res := <-runner.Run(context.Background(), types.MetaBundle{})
+
+			if tc.ExpectedError == nil {
+				assert.True(t, res.IsSuccess())
+
+				return
+			}
+
+			require.True(t, res.IsError())
+			assert.ErrorIs(t, res.Error, tc.ExpectedError)
+			assert.Equal(t, Code(1), res.Code)
+		})
+	}
+}
+
+func TestTimeoutMiddlewareDoesNotStallOtherValidators(t *testing.T) {
+	t.Parallel()
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(1, "hanging", "hangs", func(context.Context, types.MetaBundle) Result {
+				select {}
+			}),
+			NewValidatorMock(2, "fast", "completes", func(context.Context, types.MetaBundle) Result {
+				return Result{Code: 2, success: true}
+			}),
+		},
+		WithMiddleware{
+			NewTimeoutMiddleware(WithDefaultTimeout(10 * time.Millisecond)),
+		},
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+	require.Len(t, results, 2)
+
+	assert.ErrorIs(t, results[0].Error, ErrValidatorTimedOut)
+	assert.True(t, results[1].IsSuccess())
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	retryable := Result{retryable: true, Error: errors.New("temporary")}
+
+	for name, tc := range map[string]struct {
+		Options          []RetryMiddlewareOption
+		Results          []Result
+		Cancelled        bool
+		ExpectedAttempts int
+		ExpectedSuccess  bool
+	}{
+		"success": {
+			Results:          []Result{{success: true}},
+			ExpectedAttempts: 1,
+			ExpectedSuccess:  true,
+		},
+		"retryable until success": {
+			Results:          []Result{retryable, retryable, {success: true}},
+			ExpectedAttempts: 3,
+			ExpectedSuccess:  true,
+		},
+		"max attempts": {
+			Options:          []RetryMiddlewareOption{WithMaxAttempts(2)},
+			Results:          []Result{retryable, retryable, {success: true}},
+			ExpectedAttempts: 2,
+		},
+		"non-retryable error": {
+			Results:          []Result{{Error: errors.New("permanent")}, {success: true}},
+			ExpectedAttempts: 1,
+		},
+		"server-side error": {
+			Results:          []Result{{Error: fmt.Errorf("wrapped: %w", RegistryResponseError(502))}, {success: true}},
+			ExpectedAttempts: 2,
+			ExpectedSuccess:  true,
+		},
+		"client-side error": {
+			Results:          []Result{{Error: OCMResponseError(404)}, {success: true}},
+			ExpectedAttempts: 1,
+		},
+		"retry after": {
+			Options: []RetryMiddlewareOption{WithDelay(time.Hour)},
+			Results: []Result{
+				{Error: &RetryAfterError{Err: OCMResponseError(429), After: time.Millisecond}},
+				{success: true},
+			},
+			ExpectedAttempts: 2,
+			ExpectedSuccess:  true,
+		},
+		"max elapsed time": {
+			Options: []RetryMiddlewareOption{
+				WithDelay(time.Hour),
+				WithMaxElapsedTime(time.Minute),
+			},
+			Results:          []Result{retryable, {success: true}},
+			ExpectedAttempts: 1,
+		},
+		"cancelled": {
+			Options:          []RetryMiddlewareOption{WithDelay(time.Hour)},
+			Results:          []Result{retryable, {success: true}},
+			Cancelled:        true,
+			ExpectedAttempts: 1,
+		},
+		"custom classifier": {
+			Options: []RetryMiddlewareOption{
+				WithRetryClassifier(func(res Result) (bool, time.Duration) {
+					return !res.IsSuccess(), 0
+				}),
+			},
+			Results:          []Result{{FailureMsgs: []string{"flaky"}}, {success: true}},
+			ExpectedAttempts: 2,
+			ExpectedSuccess:  true,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.Cancelled {
+				cancel()
+			}
+
+			var attempts int
+
+			mw := NewRetryMiddleware(append([]RetryMiddlewareOption{
+				WithDelay(time.Millisecond),
+				WithJitter(0),
+			}, tc.Options...)...)
+
+			res := mw.Wrap(func(context.Context, types.MetaBundle) Result {
+				attempts++
+
+				return tc.Results[attempts-1]
+			})(ctx, types.MetaBundle{})
+
+			assert.Equal(t, tc.ExpectedAttempts, attempts)
+			assert.Equal(t, tc.ExpectedSuccess, res.IsSuccess())
+		})
+	}
+}
+
+func TestRetryMiddlewareBackoff(t *testing.T) {
+	t.Parallel()
+
+	mw := NewRetryMiddleware(
+		WithDelay(time.Second),
+		WithMaxDelay(5*time.Second),
+		WithJitter(0.5),
+	)
+
+	delay := time.Second
+
+	for _, expected := range []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		delay = mw.next(delay)
+		assert.Equal(t, expected, delay)
+	}
+
+	for i := 0; i < 100; i++ {
+		jittered := mw.jitter(time.Second)
+
+		assert.GreaterOrEqual(t, jittered, 500*time.Millisecond)
+		assert.LessOrEqual(t, jittered, 1500*time.Millisecond)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 )
@@ -20,9 +21,9 @@ type OCMError interface {
 // IsOCMServerSideError determines if the given error is both an instance of OCMError
 // and was caused by a server-side issue.
 func IsOCMServerSideError(err error) bool {
-	ocmErr, ok := err.(OCMError)
+	var ocmErr OCMError
 
-	return ok && ocmErr.ServerSide()
+	return errors.As(err, &ocmErr) && ocmErr.ServerSide()
 }
 
 // OCMClient abstracts behavior required for validators which request data
@@ -93,7 +94,10 @@ func (c *OCMClientImpl) QuotaRuleExists(ctx context.Context, quotaName string) (
 	}
 
 	if isHTTPError(res.Status()) {
-		return false, OCMResponseError(res.Status())
+		header := http.Header{}
+		header.Set("Retry-After", res.Header("Retry-After"))
+
+		return false, newResponseError(OCMResponseError(res.Status()), res.Status(), header)
 	}
 
 	list := struct{ Size int }{}
@@ -0,0 +1,296 @@
+// Package plugin loads validators from executables outside of this
+// repository so that policies which cannot be upstreamed can be
+// enforced alongside the registered validators.
+//
+// Plugins are executables which speak a JSON protocol over stdin and
+// stdout. Invoked with the "describe" argument a plugin writes its
+// Description to stdout. Invoked with the "validate" argument a plugin
+// reads a JSON serialized types.MetaBundle from stdin and writes its
+// Response to stdout. Plugins exiting with a non-zero status report
+// an error. Plugin validators must use codes of at least MinCode so
+// that they never clash with built-in validators.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const (
+	// APIVersion is the version of the protocol spoken with plugins.
+	APIVersion = "v1"
+	// MinCode is the lowest code a plugin validator may use. Lower
+	// codes are reserved for built-in validators.
+	MinCode validator.Code = 1000
+
+	describeArg = "describe"
+	validateArg = "validate"
+
+	// maxStderr limits the plugin output included in errors.
+	maxStderr = 4096
+	// describeTimeout bounds the time a plugin may take
+	// to describe itself when loaded.
+	describeTimeout = 10 * time.Second
+	// waitDelay bounds the time to wait for the output of a
+	// plugin killed on cancellation as processes started by
+	// the plugin may keep its output open.
+	waitDelay = time.Second
+)
+
+var ErrInvalidResponse = errors.New("invalid plugin response")
+
+// Description describes the validator provided by a plugin.
+type Description struct {
+	// APIVersion is the protocol version spoken by the plugin.
+	APIVersion string `json:"apiVersion"`
+	// Code is the code of the validator e.g. "AM1001".
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Description is displayed when listing validators.
+	Description string `json:"description"`
+	// Severity is one of "info", "warning" or "error" and
+	// defaults to "error".
+	Severity string `json:"severity,omitempty"`
+	// Capabilities are the resources the validator requires
+	// e.g. "network" or "bundles".
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Prerequisites are the codes of validators which must
+	// succeed before the validator is run.
+	Prerequisites []string `json:"prerequisites,omitempty"`
+}
+
+// Response is the result of a plugin validation. A non-empty Error
+// takes precedence over a SkipReason which takes precedence over
+// FailureMsgs. Responses with neither are successful only if
+// Success is set.
+type Response struct {
+	Success     bool     `json:"success,omitempty"`
+	FailureMsgs []string `json:"failureMsgs,omitempty"`
+	Error       string   `json:"error,omitempty"`
+	// Retryable marks errors which may not reoccur if the
+	// plugin is run again.
+	Retryable  bool   `json:"retryable,omitempty"`
+	SkipReason string `json:"skipReason,omitempty"`
+}
+
+// Load describes every executable in the given directory and returns
+// an Initializer for the validator provided by each of them. Hidden
+// files, directories and files which are not executable are ignored.
+func Load(ctx context.Context, dir string) ([]validator.Initializer, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading plugin directory: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var inits []validator.Initializer
+
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+
+		ok, err := isExecutable(path)
+		if err != nil {
+			return nil, fmt.Errorf("inspecting plugin %q: %w", path, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		init, err := NewInitializer(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		inits = append(inits, init)
+	}
+
+	return inits, nil
+}
+
+func isExecutable(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	return info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0, nil
+}
+
+// NewInitializer describes the plugin at the given path and returns an
+// Initializer for the validator it provides. An error is returned if
+// the plugin cannot be described in time or its description is invalid.
+func NewInitializer(ctx context.Context, path string) (validator.Initializer, error) {
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+
+	out, err := run(ctx, path, describeArg, nil)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ctx.Err()
+		}
+
+		return nil, fmt.Errorf("describing plugin %q: %w", path, err)
+	}
+
+	var desc Description
+
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("decoding description of plugin %q: %w", path, err)
+	}
+
+	opts, code, err := desc.baseOptions()
+	if err != nil {
+		return nil, fmt.Errorf("invalid description of plugin %q: %w", path, err)
+	}
+
+	return func(validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(code, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Validator{
+			Base: base,
+			path: path,
+		}, nil
+	}, nil
+}
+
+func (d Description) baseOptions() ([]validator.BaseOption, validator.Code, error) {
+	if d.APIVersion != APIVersion {
+		return nil, 0, fmt.Errorf("unsupported apiVersion %q; expected %q", d.APIVersion, APIVersion)
+	}
+
+	code, err := validator.ParseCode(d.Code)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if code < MinCode {
+		return nil, 0, fmt.Errorf("code %s is reserved for built-in validators; plugins must use codes of at least %s", code, MinCode)
+	}
+
+	if d.Name == "" {
+		return nil, 0, errors.New("name must not be empty")
+	}
+
+	opts := []validator.BaseOption{
+		validator.BaseName(d.Name),
+		validator.BaseDesc(d.Description),
+	}
+
+	if d.Severity != "" {
+		sev, err := validator.ParseSeverity(d.Severity)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		opts = append(opts, validator.BaseSeverity(sev))
+	}
+
+	for _, c := range d.Capabilities {
+		capability, err := validator.ParseCapability(c)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		opts = append(opts, validator.BaseCapabilities(capability))
+	}
+
+	for _, p := range d.Prerequisites {
+		prereq, err := validator.ParseCode(p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parsing prerequisite: %w", err)
+		}
+
+		opts = append(opts, validator.BasePrerequisites(prereq))
+	}
+
+	return opts, code, nil
+}
+
+// Validator runs a plugin executable for every validation.
+type Validator struct {
+	*validator.Base
+	path string
+}
+
+// Path returns the path of the plugin executable.
+func (v *Validator) Path() string { return v.path }
+
+func (v *Validator) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	in, err := json.Marshal(mb)
+	if err != nil {
+		return v.Error(fmt.Errorf("encoding metabundle: %w", err))
+	}
+
+	out, err := run(ctx, v.path, validateArg, in)
+	if err != nil {
+		return v.Error(fmt.Errorf("running plugin %q: %w", v.path, err))
+	}
+
+	var res Response
+
+	if err := json.Unmarshal(out, &res); err != nil {
+		return v.Error(fmt.Errorf("%w: %w", ErrInvalidResponse, err))
+	}
+
+	switch {
+	case res.Error != "" && res.Retryable:
+		return v.RetryableError(errors.New(res.Error))
+	case res.Error != "":
+		return v.Error(errors.New(res.Error))
+	case res.SkipReason != "":
+		return v.Skip(res.SkipReason)
+	case len(res.FailureMsgs) > 0:
+		return v.Fail(res.FailureMsgs...)
+	case res.Success:
+		return v.Success()
+	default:
+		return v.Error(fmt.Errorf("%w: neither success, failures, error nor skip reason given", ErrInvalidResponse))
+	}
+}
+
+// run invokes the plugin at path with the given argument and input
+// and returns its stdout. Errors include the stderr of the plugin.
+func run(ctx context.Context, path, arg string, in []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, arg)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			if len(msg) > maxStderr {
+				msg = msg[:maxStderr] + "..."
+			}
+
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
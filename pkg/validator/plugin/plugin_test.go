@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDescription = `{"apiVersion": "v1", "code": "AM1001", "name": "owner_label", "description": "Validate the owner label.", "severity": "warning", "capabilities": ["network"], "prerequisites": ["AM0002"]}`
+
+// writePlugin writes an executable shell script to dir which prints
+// the given description and runs the given script on validation.
+func writePlugin(t *testing.T, dir, name, description, validate string) string {
+	t.Helper()
+
+	script := fmt.Sprintf(`#!/bin/sh
+case "$1" in
+describe)
+	cat <<'EOF'
+%s
+EOF
+	;;
+validate)
+	%s
+	;;
+*)
+	exit 1
+	;;
+esac
+`, description, validate)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writePlugin(t, dir, "owner-label", testDescription, `echo '{"success": true}'`)
+	writePlugin(t, dir, ".hidden", testDescription, `echo '{"success": true}'`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o755))
+
+	inits, err := Load(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, inits, 1)
+
+	val, err := inits[0](validator.Dependencies{})
+	require.NoError(t, err)
+
+	assert.Equal(t, validator.Code(1001), val.Code())
+	assert.Equal(t, "owner_label", val.Name())
+	assert.Equal(t, "Validate the owner label.", val.Description())
+	assert.Equal(t, validator.SeverityWarning, val.Severity())
+	assert.Equal(t, []validator.Capability{validator.CapabilityNetwork}, val.Capabilities())
+	assert.Equal(t, []validator.Code{2}, val.Prerequisites())
+}
+
+func TestLoadInvalidDescription(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Description string
+		Expected    string
+	}{
+		"reserved code": {
+			Description: `{"apiVersion": "v1", "code": "AM0001", "name": "plugin"}`,
+			Expected:    "reserved for built-in validators",
+		},
+		"unsupported apiVersion": {
+			Description: `{"apiVersion": "v2", "code": "AM1001", "name": "plugin"}`,
+			Expected:    "unsupported apiVersion",
+		},
+		"missing name": {
+			Description: `{"apiVersion": "v1", "code": "AM1001"}`,
+			Expected:    "name must not be empty",
+		},
+		"unknown capability": {
+			Description: `{"apiVersion": "v1", "code": "AM1001", "name": "plugin", "capabilities": ["gpu"]}`,
+			Expected:    "unable to parse capability",
+		},
+		"invalid json": {
+			Description: `not json`,
+			Expected:    "decoding description",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			writePlugin(t, dir, "plugin", tc.Description, `echo '{"success": true}'`)
+
+			_, err := Load(context.Background(), dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.Expected)
+		})
+	}
+}
+
+func TestValidatorRun(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Validate  string
+		Assertion func(*testing.T, validator.Result)
+	}{
+		"success": {
+			// succeeds only if the metabundle is passed on stdin
+			Validate: `grep -q '"id":"test-addon"' && echo '{"success": true}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				assert.True(t, res.IsSuccess())
+			},
+		},
+		"failure": {
+			Validate: `echo '{"failureMsgs": ["missing owner label"]}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				assert.False(t, res.IsSuccess())
+				assert.Equal(t, []string{"missing owner label"}, res.FailureMsgs)
+			},
+		},
+		"error": {
+			Validate: `echo '{"error": "boom"}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				require.True(t, res.IsError())
+				assert.False(t, res.IsRetryableError())
+				assert.EqualError(t, res.Error, "boom")
+			},
+		},
+		"retryable error": {
+			Validate: `echo '{"error": "unavailable", "retryable": true}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				require.True(t, res.IsError())
+				assert.True(t, res.IsRetryableError())
+			},
+		},
+		"skipped": {
+			Validate: `echo '{"skipReason": "not applicable"}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				assert.True(t, res.IsSkipped())
+				assert.Equal(t, "not applicable", res.SkipReason)
+			},
+		},
+		"non-zero exit": {
+			Validate: `echo 'policy engine crashed' >&2; exit 2`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				require.True(t, res.IsError())
+				assert.Contains(t, res.Error.Error(), "policy engine crashed")
+			},
+		},
+		"invalid response": {
+			Validate: `echo '{}'`,
+			Assertion: func(t *testing.T, res validator.Result) {
+				require.True(t, res.IsError())
+				assert.ErrorIs(t, res.Error, ErrInvalidResponse)
+			},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writePlugin(t, t.TempDir(), "plugin", testDescription, tc.Validate)
+
+			init, err := NewInitializer(context.Background(), path)
+			require.NoError(t, err)
+
+			val, err := init(validator.Dependencies{})
+			require.NoError(t, err)
+
+			res := val.Run(context.Background(), types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "test-addon"},
+			})
+
+			assert.Equal(t, validator.Code(1001), res.Code)
+			tc.Assertion(t, res)
+		})
+	}
+}
@@ -0,0 +1,668 @@
+package validator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// RegistryClient queries container registries for image references.
+type RegistryClient interface {
+	// HasReference returns 'true' if the given reference exists and
+	// is accessible with the credentials of the client.
+	HasReference(context.Context, ImageReference) (bool, error)
+	// ResolveDigest returns the digest of the manifest the given
+	// reference points to. ErrImageNotFound is returned if the
+	// reference does not exist.
+	ResolveDigest(context.Context, ImageReference) (string, error)
+	// Platforms returns the platforms the given reference is published
+	// for. These are the platforms of the manifests of an image index
+	// or manifest list or the platform of a single image otherwise.
+	// ErrImageNotFound is returned if the reference does not exist.
+	Platforms(context.Context, ImageReference) ([]Platform, error)
+}
+
+// Deprecated: use RegistryClient instead.
+type QuayClient = RegistryClient
+
+// ImageReference references an image by tag or digest.
+type ImageReference interface {
+	// Registry returns the host of the registry serving the image.
+	Registry() string
+	// ShortName returns the repository of the image without
+	// the registry host.
+	ShortName() string
+	// Tag returns the tag or digest of the image.
+	Tag() string
+}
+
+// ErrImageNotFound is returned when an image reference does not exist
+// or is not accessible with the credentials of a RegistryClient.
+var ErrImageNotFound = errors.New("image not found")
+
+// errAccessDenied is returned when a registry refuses to
+// authenticate the client for a challenged request.
+var errAccessDenied = errors.New("access denied")
+
+// Platform is an operating system and CPU architecture
+// an image is built for.
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+func (p Platform) String() string {
+	res := p.OS + "/" + p.Architecture
+
+	if p.Variant != "" {
+		res += "/" + p.Variant
+	}
+
+	return res
+}
+
+// NewRegistryClient returns a RegistryClient for any registry
+// implementing the OCI distribution specification.
+func NewRegistryClient(opts ...RegistryClientOption) *DefaultV2RegistryClient {
+	return NewDefaultV2RegistryClient(opts...)
+}
+
+// Deprecated: use NewRegistryClient instead.
+func NewQuayClient() *DefaultV2RegistryClient {
+	return NewRegistryClient()
+}
+
+// NewDefaultV2RegistryClient returns a DefaultV2RegistryClient
+// configured with a variadic slice of options.
+func NewDefaultV2RegistryClient(opts ...RegistryClientOption) *DefaultV2RegistryClient {
+	var cfg RegistryClientConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &DefaultV2RegistryClient{
+		cfg:    cfg,
+		tokens: make(map[string]string),
+	}
+}
+
+// DefaultV2RegistryClient queries registries implementing the docker
+// registry HTTP API V2 or the OCI distribution specification. The
+// registry of each request is taken from the requested ImageReference.
+// Requests are anonymous unless the registry challenges the client in
+// which case the configured credentials of the registry are used to
+// authenticate, if any. Bearer tokens issued by registries are cached
+// per repository.
+type DefaultV2RegistryClient struct {
+	cfg RegistryClientConfig
+
+	lock   sync.Mutex
+	tokens map[string]string
+}
+
+func (c *DefaultV2RegistryClient) HasReference(ctx context.Context, ref ImageReference) (bool, error) {
+	res, err := c.manifest(ctx, http.MethodHead, ref)
+	if errors.Is(err, ErrImageNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	res.Body.Close()
+
+	return true, nil
+}
+
+func (c *DefaultV2RegistryClient) ResolveDigest(ctx context.Context, ref ImageReference) (string, error) {
+	res, err := c.manifest(ctx, http.MethodHead, ref)
+	if err != nil {
+		return "", err
+	}
+
+	res.Body.Close()
+
+	if digest := res.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// registries are not required to return the digest
+	// so it is computed from the manifest instead
+	res, err = c.manifest(ctx, http.MethodGet, ref)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, res.Body); err != nil {
+		return "", fmt.Errorf("reading manifest: %w", err)
+	}
+
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+func (c *DefaultV2RegistryClient) Platforms(ctx context.Context, ref ImageReference) ([]Platform, error) {
+	res, err := c.manifest(ctx, http.MethodGet, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	var manifest struct {
+		MediaType string               `json:"mediaType"`
+		Manifests []ocispec.Descriptor `json:"manifests"`
+		Config    ocispec.Descriptor   `json:"config"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType, _, _ = strings.Cut(res.Header.Get("Content-Type"), ";")
+	}
+
+	switch mediaType {
+	case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+		return indexPlatforms(manifest.Manifests), nil
+	case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+		platform, err := c.configPlatform(ctx, ref, manifest.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		return []Platform{platform}, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest media type %q", mediaType)
+	}
+}
+
+// indexPlatforms returns the platforms of the manifests of an image
+// index. Entries without a platform and attestation manifests, which
+// use the 'unknown' platform, are ignored.
+func indexPlatforms(descs []ocispec.Descriptor) []Platform {
+	var platforms []Platform
+
+	for _, desc := range descs {
+		if desc.Platform == nil || desc.Platform.Architecture == "unknown" {
+			continue
+		}
+
+		platforms = append(platforms, Platform{
+			OS:           desc.Platform.OS,
+			Architecture: desc.Platform.Architecture,
+			Variant:      desc.Platform.Variant,
+		})
+	}
+
+	return platforms
+}
+
+// configPlatform reads the platform of a single image from its
+// config blob.
+func (c *DefaultV2RegistryClient) configPlatform(ctx context.Context, ref ImageReference, config ocispec.Descriptor) (Platform, error) {
+	res, err := c.do(ctx, http.MethodGet, ref, "blobs/"+config.Digest.String(), config.MediaType)
+	if err != nil {
+		return Platform{}, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Platform{}, fmt.Errorf("retrieving image config: %w", RegistryResponseError(res.StatusCode))
+	}
+
+	var image ocispec.Image
+
+	if err := json.NewDecoder(res.Body).Decode(&image); err != nil {
+		return Platform{}, fmt.Errorf("decoding image config: %w", err)
+	}
+
+	return Platform{
+		OS:           image.OS,
+		Architecture: image.Architecture,
+		Variant:      image.Variant,
+	}, nil
+}
+
+// manifest requests the manifest of the given reference with the given
+// method. ErrImageNotFound is returned unless the manifest exists.
+func (c *DefaultV2RegistryClient) manifest(ctx context.Context, method string, ref ImageReference) (*http.Response, error) {
+	res, err := c.do(ctx, method, ref, "manifests/"+ref.Tag(), manifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusOK {
+		return res, nil
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+		return nil, newResponseError(RegistryResponseError(res.StatusCode), res.StatusCode, res.Header)
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s:%s", ErrImageNotFound, ref.Registry(), ref.ShortName(), ref.Tag())
+}
+
+// do sends a request for the given path relative to the repository of
+// the given reference. Requests which are challenged by the registry
+// are repeated once with authentication.
+func (c *DefaultV2RegistryClient) do(ctx context.Context, method string, ref ImageReference, path string, accept ...string) (*http.Response, error) {
+	url := fmt.Sprintf("%s/v2/%s/%s", c.registryURL(ref.Registry()), ref.ShortName(), path)
+	scope := fmt.Sprintf("repository:%s:pull", ref.ShortName())
+
+	send := func(auth string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("constructing HTTP request: %w", err)
+		}
+
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		res, err := c.cfg.HTTPClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("sending HTTP request: %w", err)
+		}
+
+		return res, nil
+	}
+
+	auth := c.cachedAuth(ref.Registry(), scope)
+
+	res, err := send(auth)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	if challenge == "" {
+		return res, nil
+	}
+
+	auth, err = c.authorize(ctx, ref.Registry(), scope, challenge)
+	if errors.Is(err, errAccessDenied) {
+		// the challenged response is returned as the
+		// reference is not accessible to the client
+		return res, nil
+	}
+
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return send(auth)
+}
+
+// cachedAuth returns the value of the 'Authorization' header for the
+// first request to the given registry and scope. Requests are anonymous
+// unless a token was issued for the scope before or a bearer token is
+// configured for the registry.
+func (c *DefaultV2RegistryClient) cachedAuth(registry, scope string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if token, ok := c.tokens[registry+"/"+scope]; ok {
+		return "Bearer " + token
+	}
+
+	if creds := c.cfg.Credentials[registry]; creds.Token != "" {
+		return "Bearer " + creds.Token
+	}
+
+	return ""
+}
+
+// authorize answers the given 'WWW-Authenticate' challenge of a registry
+// and returns the value of the 'Authorization' header to repeat the
+// challenged request with.
+func (c *DefaultV2RegistryClient) authorize(ctx context.Context, registry, scope, challenge string) (string, error) {
+	creds := c.cfg.Credentials[registry]
+
+	scheme, params := parseChallenge(challenge)
+
+	switch scheme {
+	case "basic":
+		if !creds.hasBasic() {
+			return "", errAccessDenied
+		}
+
+		return "Basic " + creds.basic(), nil
+	case "bearer":
+		if params["scope"] == "" {
+			params["scope"] = scope
+		}
+
+		token, err := c.requestToken(ctx, creds, params)
+		if errors.Is(err, errAccessDenied) {
+			return "", err
+		} else if err != nil {
+			return "", fmt.Errorf("requesting token for %s: %w", registry, err)
+		}
+
+		c.lock.Lock()
+		c.tokens[registry+"/"+scope] = token
+		c.lock.Unlock()
+
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication scheme %q of %s", scheme, registry)
+	}
+}
+
+// requestToken requests a bearer token from the realm of a challenge
+// using the given credentials if any.
+func (c *DefaultV2RegistryClient) requestToken(ctx context.Context, creds RegistryCredentials, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid realm %q", params["realm"])
+	}
+
+	query := realm.Query()
+
+	for _, key := range []string{"service", "scope"} {
+		if val := params[key]; val != "" {
+			query.Set(key, val)
+		}
+	}
+
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("constructing HTTP request: %w", err)
+	}
+
+	if creds.hasBasic() {
+		req.Header.Set("Authorization", "Basic "+creds.basic())
+	}
+
+	res, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sending HTTP request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden:
+		return "", errAccessDenied
+	case res.StatusCode != http.StatusOK:
+		return "", newResponseError(RegistryResponseError(res.StatusCode), res.StatusCode, res.Header)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding token response: %w", err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses the scheme and the parameters of a
+// 'WWW-Authenticate' header. The scheme is returned in lower case.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		var key string
+
+		key, rest, _ = strings.Cut(rest, "=")
+
+		var val string
+
+		if strings.HasPrefix(rest, `"`) {
+			val, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			val, rest, _ = strings.Cut(rest, ",")
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = val
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return strings.ToLower(scheme), params
+}
+
+// registryURL returns the base URL of the API of the given registry.
+func (c *DefaultV2RegistryClient) registryURL(registry string) string {
+	if url, ok := c.cfg.URLs[registry]; ok {
+		return url
+	}
+
+	if registry == "docker.io" {
+		registry = "registry-1.docker.io"
+	}
+
+	return "https://" + registry
+}
+
+// RegistryCredentials authenticate a client with a registry.
+type RegistryCredentials struct {
+	Username string
+	Password string
+	// Token is a bearer token sent with every request
+	// to the registry.
+	Token string
+}
+
+func (c RegistryCredentials) hasBasic() bool {
+	return c.Username != "" || c.Password != ""
+}
+
+func (c RegistryCredentials) basic() string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+}
+
+// LoadDockerConfig reads registry credentials from the docker
+// 'config.json' or pull secret at the given path.
+func LoadDockerConfig(path string) (WithRegistryCredentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading docker config: %w", err)
+	}
+
+	return ParseDockerConfig(data)
+}
+
+// ParseDockerConfig parses registry credentials from a docker
+// 'config.json' or from a kubernetes pull secret in JSON or YAML
+// format containing a '.dockerconfigjson' key.
+func ParseDockerConfig(data []byte) (WithRegistryCredentials, error) {
+	var config struct {
+		Auths map[string]struct {
+			Auth          string `json:"auth"`
+			Username      string `json:"username"`
+			Password      string `json:"password"`
+			RegistryToken string `json:"registrytoken"`
+		} `json:"auths"`
+		Data       map[string]string `json:"data"`
+		StringData map[string]string `json:"stringData"`
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("decoding docker config: %w", err)
+	}
+
+	if config.Auths == nil {
+		if raw, ok := config.StringData[dockerConfigJSONKey]; ok {
+			return ParseDockerConfig([]byte(raw))
+		}
+
+		if encoded, ok := config.Data[dockerConfigJSONKey]; ok {
+			raw, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("decoding pull secret: %w", err)
+			}
+
+			return ParseDockerConfig(raw)
+		}
+	}
+
+	creds := make(WithRegistryCredentials, len(config.Auths))
+
+	for registry, auth := range config.Auths {
+		cred := RegistryCredentials{
+			Username: auth.Username,
+			Password: auth.Password,
+			Token:    auth.RegistryToken,
+		}
+
+		if auth.Auth != "" {
+			raw, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("decoding credentials of %s: %w", registry, err)
+			}
+
+			cred.Username, cred.Password, _ = strings.Cut(string(raw), ":")
+		}
+
+		creds[normalizeRegistry(registry)] = cred
+	}
+
+	return creds, nil
+}
+
+const dockerConfigJSONKey = ".dockerconfigjson"
+
+// normalizeRegistry returns the host of a registry given as
+// key of the 'auths' of a docker config.
+func normalizeRegistry(registry string) string {
+	host := registry
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+
+	host, _, _ = strings.Cut(host, "/")
+
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	default:
+		return host
+	}
+}
+
+type RegistryClientConfig struct {
+	// URLs overrides the base URLs of the API of registries keyed
+	// by registry host. The URL of all other registries is derived
+	// from their host.
+	URLs map[string]string
+	// Credentials authenticate requests keyed by registry host.
+	// Requests to registries without credentials are anonymous.
+	Credentials map[string]RegistryCredentials
+	// HTTPClient sends the requests to registries.
+	HTTPClient *http.Client
+}
+
+func (c *RegistryClientConfig) Option(opts ...RegistryClientOption) {
+	for _, opt := range opts {
+		opt.ConfigureRegistryClient(c)
+	}
+}
+
+func (c *RegistryClientConfig) Default() {
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{}
+	}
+}
+
+type RegistryClientOption interface {
+	ConfigureRegistryClient(*RegistryClientConfig)
+}
+
+// WithRegistryURLs overrides the base URLs of the API of
+// registries keyed by registry host.
+type WithRegistryURLs map[string]string
+
+func (w WithRegistryURLs) ConfigureRegistryClient(c *RegistryClientConfig) {
+	if c.URLs == nil {
+		c.URLs = make(map[string]string, len(w))
+	}
+
+	for registry, url := range w {
+		c.URLs[registry] = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithRegistryCredentials authenticates requests to registries
+// with the given credentials keyed by registry host.
+type WithRegistryCredentials map[string]RegistryCredentials
+
+func (w WithRegistryCredentials) ConfigureRegistryClient(c *RegistryClientConfig) {
+	if c.Credentials == nil {
+		c.Credentials = make(map[string]RegistryCredentials, len(w))
+	}
+
+	for registry, creds := range w {
+		c.Credentials[registry] = creds
+	}
+}
+
+// WithRegistryTokens authenticates requests to registries
+// with the given bearer tokens keyed by registry host.
+type WithRegistryTokens map[string]string
+
+func (w WithRegistryTokens) ConfigureRegistryClient(c *RegistryClientConfig) {
+	creds := make(WithRegistryCredentials, len(w))
+
+	for registry, token := range w {
+		creds[registry] = RegistryCredentials{Token: token}
+	}
+
+	creds.ConfigureRegistryClient(c)
+}
+
+// WithHTTPClient sends requests to registries with the given client.
+type WithHTTPClient struct{ *http.Client }
+
+func (w WithHTTPClient) ConfigureRegistryClient(c *RegistryClientConfig) { c.HTTPClient = w.Client }
+
+const (
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// manifestMediaTypes are accepted when requesting manifests so that
+// references to image indexes and manifest lists can be resolved.
+var manifestMediaTypes = []string{
+	ocispec.MediaTypeImageIndex,
+	ocispec.MediaTypeImageManifest,
+	mediaTypeDockerManifestList,
+	mediaTypeDockerManifest,
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	imageparser "github.com/novln/docker-parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultV2RegistryClientImplInterface(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(RegistryClient), new(DefaultV2RegistryClient))
+}
+
+func TestDefaultV2RegistryClientHasReference(t *testing.T) {
+	t.Parallel()
+
+	manifests := map[string]bool{
+		"/v2/public/app/manifests/v1.0.0": true,
+		"/v2/public/app/manifests/sha256:bdc32a600202d36fec4524dbec177e9313ef82ad4bda5bd24d4b75236ca8a482": true,
+		"/v2/private/app/manifests/v1.0.0": true,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case strings.HasPrefix(r.URL.Path, "/v2/unavailable/"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.HasPrefix(r.URL.Path, "/v2/private/") && r.Header.Get("Authorization") != "Bearer secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case manifests[r.URL.Path]:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewDefaultV2RegistryClient(
+		WithRegistryURLs{
+			"registry.test":  srv.URL,
+			"anonymous.test": srv.URL,
+		},
+		WithRegistryTokens{"registry.test": "secret"},
+		WithHTTPClient{Client: srv.Client()},
+	)
+
+	for name, tc := range map[string]struct {
+		Image       string
+		Expected    bool
+		ShouldError bool
+	}{
+		"existing tag": {
+			Image:    "registry.test/public/app:v1.0.0",
+			Expected: true,
+		},
+		"existing digest": {
+			Image:    "registry.test/public/app@sha256:bdc32a600202d36fec4524dbec177e9313ef82ad4bda5bd24d4b75236ca8a482",
+			Expected: true,
+		},
+		"missing tag": {
+			Image:    "registry.test/public/app:v2.0.0",
+			Expected: false,
+		},
+		"private image with token": {
+			Image:    "registry.test/private/app:v1.0.0",
+			Expected: true,
+		},
+		"private image without token": {
+			Image:    "anonymous.test/private/app:v1.0.0",
+			Expected: false,
+		},
+		"unavailable registry": {
+			Image:       "registry.test/unavailable/app:v1.0.0",
+			ShouldError: true,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := imageparser.Parse(tc.Image)
+			require.NoError(t, err)
+
+			ok, err := client.HasReference(context.Background(), ref)
+			if tc.ShouldError {
+				require.Error(t, err)
+				assert.True(t, IsServerSideError(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, ok)
+		})
+	}
+}
+
+func TestParseDockerConfig(t *testing.T) {
+	t.Parallel()
+
+	const config = `{
+  "auths": {
+    "quay.io": {"auth": "cm9ib3Q6c2VjcmV0"},
+    "https://index.docker.io/v1/": {"username": "user", "password": "pass"},
+    "registry.test": {"registrytoken": "token"}
+  }
+}`
+
+	expected := WithRegistryCredentials{
+		"quay.io":       {Username: "robot", Password: "secret"},
+		"docker.io":     {Username: "user", Password: "pass"},
+		"registry.test": {Token: "token"},
+	}
+
+	for name, tc := range map[string]struct {
+		Data string
+	}{
+		"docker config": {
+			Data: config,
+		},
+		"pull secret": {
+			Data: `apiVersion: v1
+kind: Secret
+type: kubernetes.io/dockerconfigjson
+data:
+  .dockerconfigjson: ` + base64.StdEncoding.EncodeToString([]byte(config)),
+		},
+		"pull secret string data": {
+			Data: `{"kind": "Secret", "stringData": {".dockerconfigjson": ` + strconv.Quote(config) + `}}`,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			creds, err := ParseDockerConfig([]byte(tc.Data))
+			require.NoError(t, err)
+			assert.Equal(t, expected, creds)
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	t.Parallel()
+
+	scheme, params := parseChallenge(`Bearer realm="https://auth.test/token",service="registry.test",scope="repository:app-sre/app:pull,push"`)
+
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.test/token",
+		"service": "registry.test",
+		"scope":   "repository:app-sre/app:pull,push",
+	}, params)
+}
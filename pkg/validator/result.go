@@ -1,15 +1,32 @@
 package validator
 
+import "time"
+
 // Result encapsulates the status and reason for the result of
 // a Validator task running against a types.MetaBundle.
 type Result struct {
 	Code        Code
 	Name        string
 	Description string
+	Severity    Severity
 	FailureMsgs []string
 	Error       error
-	retryable   bool
-	success     bool
+	// SuppressedMsgs are the failure messages of this Result
+	// which were accepted by a Suppression. They are not
+	// included in FailureMsgs.
+	SuppressedMsgs []SuppressedMsg
+	// SkipReason explains why the Validator task was skipped
+	// if it did not run.
+	SkipReason string
+	// Duration is the time the Validator task took
+	// including any retries.
+	Duration time.Duration
+	// Attempts is the number of times the Validator task
+	// ran if it was retried by middleware.
+	Attempts  int
+	retryable bool
+	success   bool
+	skipped   bool
 }
 
 // IsSuccess returns 'true' if the Validator task which
@@ -24,6 +41,39 @@ func (r Result) IsError() bool { return r.Error != nil }
 // returned it encountered an error, but the error can be retried.
 func (r Result) IsRetryableError() bool { return r.retryable }
 
+// Outcome returns the outcome of the Validator task as one of
+// 'success', 'failure', 'error' or 'skipped'. Suppressions are
+// not considered.
+func (r Result) Outcome() string {
+	switch {
+	case r.IsSuccess():
+		return "success"
+	case r.IsSkipped():
+		return "skipped"
+	case r.IsError():
+		return "error"
+	default:
+		return "failure"
+	}
+}
+
+// IsSkipped returns 'true' if the Validator task which
+// returned it was skipped and did not check anything.
+func (r Result) IsSkipped() bool { return r.skipped }
+
+// IsSuppressed returns 'true' if all failures of the Validator
+// task which returned it were accepted by suppressions.
+func (r Result) IsSuppressed() bool {
+	return len(r.SuppressedMsgs) > 0 && len(r.FailureMsgs) == 0
+}
+
+// SuppressedMsg is a failure message which was accepted
+// by a Suppression.
+type SuppressedMsg struct {
+	Msg         string
+	Suppression Suppression
+}
+
 // ResultList is a sortable slice of Result instances.
 type ResultList []Result
 
@@ -32,10 +82,11 @@ func (l ResultList) Less(i, j int) bool { return l[i].Code < l[j].Code }
 func (l ResultList) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 
 // HasFailure returns 'true' if any of the ResultList members
-// are failures or errors.
+// are failures or errors. Suppressed failures and skipped
+// results are ignored.
 func (l ResultList) HasFailure() bool {
 	for _, r := range l {
-		if r.IsSuccess() {
+		if r.IsSuccess() || r.IsSkipped() || r.IsSuppressed() {
 			continue
 		}
 
@@ -45,6 +96,36 @@ func (l ResultList) HasFailure() bool {
 	return false
 }
 
+// HasFailureAtOrAbove returns 'true' if any of the ResultList
+// members are failures with a severity at or above the given
+// severity. Errors, skipped results and suppressed failures are
+// not considered failures.
+func (l ResultList) HasFailureAtOrAbove(sev Severity) bool {
+	for _, r := range l {
+		if r.IsSuccess() || r.IsSkipped() || r.IsError() || r.IsSuppressed() {
+			continue
+		}
+
+		if r.Severity >= sev {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSkipped returns 'true' if any of the ResultList
+// members were skipped.
+func (l ResultList) HasSkipped() bool {
+	for _, r := range l {
+		if r.IsSkipped() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Errors returns a slice of errors from the ResultList
 // members. If no errors were encountered then an empty slice
 // is returned.
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
@@ -27,8 +29,11 @@ type Initializer func(Dependencies) (Validator, error)
 type Dependencies struct {
 	Logger          logr.Logger
 	OCMClient       OCMClient
-	QuayClient      QuayClient
+	RegistryClient  RegistryClient
 	ValidatorConfig ValidatorConfig
+
+	// Deprecated: use RegistryClient instead.
+	QuayClient QuayClient
 }
 
 type ValidatorConfig struct {
@@ -66,13 +71,18 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 	deps := Dependencies{
 		Logger:          cfg.Logger,
 		OCMClient:       cfg.OCMClient,
-		QuayClient:      cfg.QuayClient,
+		RegistryClient:  cfg.RegistryClient,
 		ValidatorConfig: valCfg,
+		QuayClient:      cfg.RegistryClient,
 	}
 
 	entries := make(map[Code]validatorEntry)
 
-	for _, init := range cfg.Initializers {
+	inits := make([]Initializer, 0, len(cfg.Initializers)+len(cfg.AdditionalInitializers))
+	inits = append(inits, cfg.Initializers...)
+	inits = append(inits, cfg.AdditionalInitializers...)
+
+	for _, init := range inits {
 		val, err := init(deps)
 		if err != nil {
 			return nil, err
@@ -86,22 +96,102 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 			)
 		}
 
+		if sev, ok := cfg.SeverityOverrides[val.Code()]; ok {
+			val = &severityOverride{
+				Validator: val,
+				severity:  sev,
+			}
+		}
+
 		entries[val.Code()] = validatorEntry{
 			Validator: val,
 		}
 	}
 
+	if err := checkPrerequisites(entries); err != nil {
+		return nil, err
+	}
+
 	return &Runner{
 		cfg:     cfg,
 		entries: entries,
 	}, nil
 }
 
+// checkPrerequisites returns an error if the prerequisites of the
+// given validators form a cycle. Prerequisites which are not
+// registered are ignored.
+func checkPrerequisites(entries map[Code]validatorEntry) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[Code]int, len(entries))
+
+	var visit func(code Code, path []Code) error
+
+	visit = func(code Code, path []Code) error {
+		path = append(path, code)
+
+		switch state[code] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := make([]string, 0, len(path))
+
+			for _, c := range path {
+				cycle = append(cycle, c.String())
+			}
+
+			return fmt.Errorf("validator prerequisites form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[code] = visiting
+
+		for _, prereq := range entries[code].Prerequisites() {
+			if _, ok := entries[prereq]; !ok {
+				continue
+			}
+
+			if err := visit(prereq, path); err != nil {
+				return err
+			}
+		}
+
+		state[code] = visited
+
+		return nil
+	}
+
+	codes := make([]Code, 0, len(entries))
+
+	for code := range entries {
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	for _, code := range codes {
+		if err := visit(code, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type Runner struct {
 	cfg     RunnerConfig
 	entries map[Code]validatorEntry
 }
 
+// Run runs the validators matching the given filters against the
+// given MetaBundle and returns a channel of their results which is
+// closed once all validators are finished. Validators are run after
+// their prerequisites and are reported as skipped if a prerequisite
+// does not succeed. Prerequisites not matching the given filters are
+// not waited on.
 func (r *Runner) Run(ctx context.Context, mb types.MetaBundle, filters ...Filter) <-chan Result {
 	resultCh := make(chan Result)
 
@@ -109,17 +199,39 @@ func (r *Runner) Run(ctx context.Context, mb types.MetaBundle, filters ...Filter
 
 	vals := r.GetValidators(filters...)
 
+	pending := make(map[Code]*pendingResult, len(vals))
+
+	for _, v := range vals {
+		pending[v.Code()] = &pendingResult{
+			done: make(chan struct{}),
+		}
+	}
+
+	var slots chan struct{}
+
+	if r.cfg.MaxConcurrency > 0 {
+		slots = make(chan struct{}, r.cfg.MaxConcurrency)
+	}
+
 	wg.Add(len(vals))
 
 	for _, val := range vals {
 		go func(v Validator) {
 			defer wg.Done()
 
-			run := r.applyMiddleware(v.Run)
+			res, ok := r.runValidator(ctx, v, mb, pending, slots)
+
+			p := pending[v.Code()]
+			p.res = res
+			close(p.done)
+
+			if !ok {
+				return
+			}
 
 			select {
 			case <-ctx.Done():
-			case resultCh <- run(ctx, mb):
+			case resultCh <- res:
 			}
 		}(val)
 	}
@@ -132,6 +244,76 @@ func (r *Runner) Run(ctx context.Context, mb types.MetaBundle, filters ...Filter
 	return resultCh
 }
 
+// pendingResult holds the Result of a Validator
+// once its done channel is closed.
+type pendingResult struct {
+	done chan struct{}
+	res  Result
+}
+
+// runValidator waits for the prerequisites of the given Validator and
+// runs it once one of the given slots is free. A nil slots channel
+// does not limit concurrency. False is returned if the context is
+// cancelled before the Validator could be run.
+func (r *Runner) runValidator(ctx context.Context, v Validator, mb types.MetaBundle, pending map[Code]*pendingResult, slots chan struct{}) (Result, bool) {
+	run := r.applyMiddleware(v.Run)
+
+	reason, skip := r.skipReason(v)
+
+	for _, code := range v.Prerequisites() {
+		if skip {
+			break
+		}
+
+		p, ok := pending[code]
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{}, false
+		case <-p.done:
+		}
+
+		reason, skip = prerequisiteSkipReason(code, p.res)
+	}
+
+	if skip {
+		run = skipped(v, reason)
+	} else if slots != nil {
+		select {
+		case <-ctx.Done():
+			return Result{}, false
+		case slots <- struct{}{}:
+		}
+
+		defer func() { <-slots }()
+	}
+
+	start := time.Now()
+
+	res := run(ContextWithValidator(ctx, v), mb)
+	res.Duration = time.Since(start)
+
+	return res, true
+}
+
+// prerequisiteSkipReason returns the reason for skipping the dependents
+// of the prerequisite with the given code if its Result is unsuccessful.
+func prerequisiteSkipReason(code Code, res Result) (string, bool) {
+	switch {
+	case res.IsSuccess():
+		return "", false
+	case res.IsSkipped():
+		return fmt.Sprintf("prerequisite %s was skipped", code), true
+	case res.IsError():
+		return fmt.Sprintf("prerequisite %s returned an error", code), true
+	default:
+		return fmt.Sprintf("prerequisite %s failed", code), true
+	}
+}
+
 func (r *Runner) GetValidators(filters ...Filter) []Validator {
 	var result ValidatorList
 
@@ -148,6 +330,30 @@ func (r *Runner) GetValidators(filters ...Filter) []Validator {
 	return result
 }
 
+// skipReason returns the reason of the first skip rule
+// matching the given Validator if any.
+func (r *Runner) skipReason(v Validator) (string, bool) {
+	for _, rule := range r.cfg.Skipped {
+		if rule.Filter != nil && rule.Filter(v) {
+			return rule.Reason, true
+		}
+	}
+
+	return "", false
+}
+
+// skipped returns a RunFunc which reports the given
+// Validator as skipped without running it.
+func skipped(v Validator, reason string) RunFunc {
+	return func(context.Context, types.MetaBundle) Result {
+		res := resultFor(v)
+		res.SkipReason = reason
+		res.skipped = true
+
+		return res
+	}
+}
+
 func (r *Runner) applyMiddleware(run RunFunc) RunFunc {
 	res := run
 
@@ -159,12 +365,16 @@ func (r *Runner) applyMiddleware(run RunFunc) RunFunc {
 }
 
 type RunnerConfig struct {
-	Initializers     []Initializer
-	Logger           logr.Logger
-	Middleware       []Middleware
-	OCMClient        OCMClient
-	QuayClient       QuayClient
-	ValidatorOptions []ValidatorOption
+	Initializers           []Initializer
+	AdditionalInitializers []Initializer
+	Logger                 logr.Logger
+	MaxConcurrency         int
+	Middleware             []Middleware
+	OCMClient              OCMClient
+	RegistryClient         RegistryClient
+	SeverityOverrides      map[Code]Severity
+	Skipped                []WithSkipped
+	ValidatorOptions       []ValidatorOption
 }
 
 func (c *RunnerConfig) Option(opts ...RunnerOption) {
@@ -186,8 +396,8 @@ func (c *RunnerConfig) Default() {
 		c.OCMClient = NewDisconnectedOCMClient()
 	}
 
-	if c.QuayClient == nil {
-		c.QuayClient = NewQuayClient()
+	if c.RegistryClient == nil {
+		c.RegistryClient = NewRegistryClient()
 	}
 }
 
@@ -203,6 +413,21 @@ type WithInitializers []Initializer
 
 func (i WithInitializers) ApplyToRunnerConfig(c *RunnerConfig) { c.Initializers = i }
 
+// WithMaxConcurrency limits the number of validators run
+// concurrently. Values less than one do not limit concurrency.
+type WithMaxConcurrency int
+
+func (w WithMaxConcurrency) ApplyToRunnerConfig(c *RunnerConfig) { c.MaxConcurrency = int(w) }
+
+// WithAdditionalInitializers initializes the validators of the given
+// initializers in addition to the registered validators or those given
+// with WithInitializers.
+type WithAdditionalInitializers []Initializer
+
+func (i WithAdditionalInitializers) ApplyToRunnerConfig(c *RunnerConfig) {
+	c.AdditionalInitializers = append(c.AdditionalInitializers, i...)
+}
+
 type WithMiddleware []Middleware
 
 func (m WithMiddleware) ApplyToRunnerConfig(c *RunnerConfig) { c.Middleware = m }
@@ -211,9 +436,40 @@ type WithOCMClient struct{ OCMClient }
 
 func (o WithOCMClient) ApplyToRunnerConfig(c *RunnerConfig) { c.OCMClient = o }
 
+type WithRegistryClient struct{ RegistryClient }
+
+func (q WithRegistryClient) ApplyToRunnerConfig(c *RunnerConfig) { c.RegistryClient = q }
+
+// Deprecated: use WithRegistryClient instead.
 type WithQuayClient struct{ QuayClient }
 
-func (q WithQuayClient) ApplyToRunnerConfig(c *RunnerConfig) { c.QuayClient = q }
+func (q WithQuayClient) ApplyToRunnerConfig(c *RunnerConfig) { c.RegistryClient = q.QuayClient }
+
+// WithSeverityOverrides replaces the severity of the validators
+// matching the given codes.
+type WithSeverityOverrides map[Code]Severity
+
+func (w WithSeverityOverrides) ApplyToRunnerConfig(c *RunnerConfig) {
+	if c.SeverityOverrides == nil {
+		c.SeverityOverrides = make(map[Code]Severity, len(w))
+	}
+
+	for code, sev := range w {
+		c.SeverityOverrides[code] = sev
+	}
+}
+
+// WithSkipped reports the validators matching Filter as skipped
+// with the given Reason instead of running them. The first
+// matching option determines the reason if several are given.
+type WithSkipped struct {
+	Filter Filter
+	Reason string
+}
+
+func (w WithSkipped) ApplyToRunnerConfig(c *RunnerConfig) {
+	c.Skipped = append(c.Skipped, w)
+}
 
 type WithValidatorOptions []ValidatorOption
 
@@ -221,6 +477,22 @@ func (w WithValidatorOptions) ApplyToRunnerConfig(c *RunnerConfig) {
 	c.ValidatorOptions = append(c.ValidatorOptions, w...)
 }
 
+// severityOverride replaces the severity of a wrapped Validator
+// and of the results it returns.
+type severityOverride struct {
+	Validator
+	severity Severity
+}
+
+func (o *severityOverride) Severity() Severity { return o.severity }
+
+func (o *severityOverride) Run(ctx context.Context, mb types.MetaBundle) Result {
+	res := o.Validator.Run(ctx, mb)
+	res.Severity = o.severity
+
+	return res
+}
+
 type validatorEntry struct {
 	Validator
 }
@@ -2,6 +2,9 @@ package validator
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -137,6 +140,38 @@ func TestRunnerMiddleware(t *testing.T) {
 	assert.Equal(t, expectedCount, actualCount)
 }
 
+func TestRunnerSeverityOverrides(t *testing.T) {
+	t.Parallel()
+
+	const (
+		code = Code(0)
+		name = "dummy_validator"
+		desc = "this is a dummy validator"
+	)
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(
+				code,
+				name,
+				desc,
+				func(context.Context, types.MetaBundle) Result {
+					return Result{Code: code, Severity: SeverityError}
+				},
+			),
+		},
+		WithSeverityOverrides{code: SeverityInfo},
+	)
+	require.NoError(t, err)
+
+	vals := runner.GetValidators()
+	require.Len(t, vals, 1)
+	assert.Equal(t, SeverityInfo, vals[0].Severity())
+
+	res := <-runner.Run(context.TODO(), types.MetaBundle{})
+	assert.Equal(t, SeverityInfo, res.Severity)
+}
+
 func NewValidatorMock(
 	code Code,
 	name, desc string,
@@ -164,3 +199,234 @@ type ValidatorMock struct {
 func (v ValidatorMock) Run(ctx context.Context, mb types.MetaBundle) Result {
 	return v.runner(ctx, mb)
 }
+
+func TestRunnerSkipped(t *testing.T) {
+	t.Parallel()
+
+	var ran bool
+
+	offline, err := NewBase(1, BaseName("offline"))
+	require.NoError(t, err)
+
+	network, err := NewBase(2,
+		BaseName("network"),
+		BaseSeverity(SeverityWarning),
+		BaseCapabilities(CapabilityNetwork),
+	)
+	require.NoError(t, err)
+
+	runner, err := NewRunner(
+		WithInitializers{
+			func(Dependencies) (Validator, error) {
+				return &ValidatorMock{
+					Base: offline,
+					runner: func(context.Context, types.MetaBundle) Result {
+						return offline.Success()
+					},
+				}, nil
+			},
+			func(Dependencies) (Validator, error) {
+				return &ValidatorMock{
+					Base: network,
+					runner: func(context.Context, types.MetaBundle) Result {
+						ran = true
+
+						return network.Fail("failed")
+					},
+				}, nil
+			},
+		},
+		WithSkipped{
+			Filter: RequiresAnyCapability(CapabilityNetwork),
+			Reason: "offline",
+		},
+		WithSkipped{
+			Filter: MatchesCodes(2),
+			Reason: "ignored",
+		},
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.TODO(), types.MetaBundle{}) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+	require.Len(t, results, 2)
+
+	assert.True(t, results[0].IsSuccess())
+	assert.False(t, results[0].IsSkipped())
+
+	assert.False(t, ran)
+	assert.True(t, results[1].IsSkipped())
+	assert.False(t, results[1].IsSuccess())
+	assert.Equal(t, "offline", results[1].SkipReason)
+	assert.Equal(t, "network", results[1].Name)
+	assert.Equal(t, SeverityWarning, results[1].Severity)
+	assert.False(t, results.HasFailure())
+}
+
+// newPrerequisiteMock returns an Initializer for a validator with the
+// given code and prerequisites returning the result of the given function.
+func newPrerequisiteMock(code Code, run func(*Base) Result, prereqs ...Code) Initializer {
+	return func(Dependencies) (Validator, error) {
+		base, err := NewBase(code,
+			BaseName("dummy_validator"),
+			BasePrerequisites(prereqs...),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ValidatorMock{
+			Base: base,
+			runner: func(context.Context, types.MetaBundle) Result {
+				return run(base)
+			},
+		}, nil
+	}
+}
+
+func TestRunnerPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Prerequisite       func(*Base) Result
+		Filters            []Filter
+		ExpectedSkipReason string
+	}{
+		"prerequisite succeeds": {
+			Prerequisite: func(b *Base) Result { return b.Success() },
+		},
+		"prerequisite fails": {
+			Prerequisite:       func(b *Base) Result { return b.Fail("failed") },
+			ExpectedSkipReason: "prerequisite AM0001 failed",
+		},
+		"prerequisite errors": {
+			Prerequisite:       func(b *Base) Result { return b.Error(errors.New("boom")) },
+			ExpectedSkipReason: "prerequisite AM0001 returned an error",
+		},
+		"prerequisite skipped": {
+			Prerequisite:       func(b *Base) Result { return b.Skip("skipped") },
+			ExpectedSkipReason: "prerequisite AM0001 was skipped",
+		},
+		"prerequisite filtered out": {
+			Prerequisite: func(b *Base) Result { return b.Fail("failed") },
+			Filters:      []Filter{MatchesCodes(2)},
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var ran bool
+
+			runner, err := NewRunner(
+				WithInitializers{
+					newPrerequisiteMock(1, tc.Prerequisite),
+					newPrerequisiteMock(2, func(b *Base) Result {
+						ran = true
+
+						return b.Success()
+					}, 1),
+				},
+			)
+			require.NoError(t, err)
+
+			var dependent Result
+
+			for res := range runner.Run(context.TODO(), types.MetaBundle{}, tc.Filters...) {
+				if res.Code == 2 {
+					dependent = res
+				}
+			}
+
+			if tc.ExpectedSkipReason == "" {
+				assert.True(t, ran)
+				assert.True(t, dependent.IsSuccess())
+
+				return
+			}
+
+			assert.False(t, ran)
+			assert.True(t, dependent.IsSkipped())
+			assert.Equal(t, tc.ExpectedSkipReason, dependent.SkipReason)
+		})
+	}
+}
+
+func TestRunnerPrerequisiteCycle(t *testing.T) {
+	t.Parallel()
+
+	success := func(b *Base) Result { return b.Success() }
+
+	_, err := NewRunner(
+		WithInitializers{
+			newPrerequisiteMock(1, success, 3),
+			newPrerequisiteMock(2, success, 1),
+			newPrerequisiteMock(3, success, 2),
+		},
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AM0001 -> AM0003 -> AM0002 -> AM0001")
+
+	_, err = NewRunner(
+		WithInitializers{
+			newPrerequisiteMock(1, success),
+			newPrerequisiteMock(2, success, 1, 4),
+		},
+	)
+	assert.NoError(t, err, "unregistered prerequisites are ignored")
+}
+
+func TestRunnerMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numValidators  = 8
+		maxConcurrency = 2
+	)
+
+	var (
+		mu               sync.Mutex
+		running, maxSeen int
+	)
+
+	run := func(b *Base) Result {
+		mu.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		return b.Success()
+	}
+
+	var inits WithInitializers
+
+	for i := 1; i <= numValidators; i++ {
+		inits = append(inits, newPrerequisiteMock(Code(i), run))
+	}
+
+	runner, err := NewRunner(inits, WithMaxConcurrency(maxConcurrency))
+	require.NoError(t, err)
+
+	var count int
+
+	for range runner.Run(context.TODO(), types.MetaBundle{}) {
+		count++
+	}
+
+	assert.Equal(t, numValidators, count)
+	assert.Equal(t, maxConcurrency, maxSeen)
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity describes how a failed validation should be treated.
+// Severities are ordered such that SeverityInfo < SeverityWarning
+// < SeverityError.
+type Severity int
+
+const (
+	// SeverityInfo marks failures which are purely informational.
+	SeverityInfo Severity = iota + 1
+	// SeverityWarning marks failures which should be addressed,
+	// but are not critical.
+	SeverityWarning
+	// SeverityError marks failures which must be addressed.
+	SeverityError
+)
+
+// Severities returns all valid severities in ascending order.
+func Severities() []Severity {
+	return []Severity{
+		SeverityInfo,
+		SeverityWarning,
+		SeverityError,
+	}
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("unknown severity <%d>", int(s))
+	}
+}
+
+// ParseSeverity converts the given string to a Severity value.
+// An error is returned if the string does not name a known severity.
+func ParseSeverity(maybeSeverity string) (Severity, error) {
+	for _, s := range Severities() {
+		if strings.EqualFold(s.String(), maybeSeverity) {
+			return s, nil
+		}
+	}
+
+	return Severity(0), fmt.Errorf("unable to parse severity from '%s'", maybeSeverity)
+}
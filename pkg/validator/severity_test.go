@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input          string
+		Expected       Severity
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"info": {
+			Input:          "info",
+			Expected:       SeverityInfo,
+			ErrorAssertion: assert.NoError,
+		},
+		"warning": {
+			Input:          "warning",
+			Expected:       SeverityWarning,
+			ErrorAssertion: assert.NoError,
+		},
+		"upper case error": {
+			Input:          "ERROR",
+			Expected:       SeverityError,
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown": {
+			Input:          "critical",
+			Expected:       Severity(0),
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sev, err := ParseSeverity(tc.Input)
+			tc.ErrorAssertion(t, err)
+
+			assert.Equal(t, tc.Expected, sev)
+		})
+	}
+}
+
+func TestBaseSeverity(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1))
+	require.NoError(t, err)
+
+	assert.Equal(t, SeverityError, base.Severity())
+	assert.Equal(t, SeverityError, base.Fail("failed").Severity)
+
+	base, err = NewBase(Code(1), BaseSeverity(SeverityWarning))
+	require.NoError(t, err)
+
+	assert.Equal(t, SeverityWarning, base.Severity())
+	assert.Equal(t, SeverityWarning, base.Fail("failed").Severity)
+}
+
+func TestResultListHasFailureAtOrAbove(t *testing.T) {
+	t.Parallel()
+
+	warn, err := NewBase(Code(1), BaseSeverity(SeverityWarning))
+	require.NoError(t, err)
+
+	results := ResultList{
+		warn.Fail("advisory"),
+		warn.Success(),
+	}
+
+	assert.True(t, results.HasFailureAtOrAbove(SeverityInfo))
+	assert.True(t, results.HasFailureAtOrAbove(SeverityWarning))
+	assert.False(t, results.HasFailureAtOrAbove(SeverityError))
+	assert.True(t, results.HasFailure())
+}
@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetValidationStatus records the results of validating the given
+// MetaBundle in the given status. The resolved index image, the
+// validated bundles and the per-validator results replace any
+// previous values and the 'Validated' condition is updated to
+// summarize the results for the given generation. Other conditions
+// are left untouched.
+func SetValidationStatus(status *v1alpha1.ValidationStatus, mb types.MetaBundle, results ResultList, generation int64) {
+	status.ObservedGeneration = generation
+	status.IndexImage = ""
+
+	if mb.AddonMeta != nil && mb.AddonMeta.IndexImage != nil {
+		status.IndexImage = *mb.AddonMeta.IndexImage
+	}
+
+	status.Bundles = make([]v1alpha1.BundleReference, 0, len(mb.Bundles))
+
+	for _, b := range mb.Bundles {
+		status.Bundles = append(status.Bundles, v1alpha1.BundleReference{
+			Name:    b.Name,
+			Version: b.Version,
+			Image:   b.BundleImage,
+		})
+	}
+
+	status.Results = make([]v1alpha1.ValidatorResult, 0, len(results))
+
+	for _, res := range results {
+		status.Results = append(status.Results, newValidatorResult(res))
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, ValidatedCondition(results, generation))
+}
+
+func newValidatorResult(res Result) v1alpha1.ValidatorResult {
+	vr := v1alpha1.ValidatorResult{
+		Code:     res.Code.String(),
+		Name:     res.Name,
+		Severity: res.Severity.String(),
+	}
+
+	switch {
+	case res.IsSuccess():
+		vr.Status = v1alpha1.ValidatorResultSuccess
+	case res.IsSkipped():
+		vr.Status = v1alpha1.ValidatorResultSkipped
+	case res.IsError():
+		vr.Status = v1alpha1.ValidatorResultError
+		vr.Error = res.Error.Error()
+	case res.IsSuppressed():
+		vr.Status = v1alpha1.ValidatorResultSuppressed
+	default:
+		vr.Status = v1alpha1.ValidatorResultFailure
+		vr.FailureMsgs = res.FailureMsgs
+	}
+
+	for _, msg := range res.SuppressedMsgs {
+		vr.SuppressedMsgs = append(vr.SuppressedMsgs, msg.Msg)
+	}
+
+	return vr
+}
+
+// maxConditionMessageLength is the maximum length
+// of the message of a metav1.Condition.
+const maxConditionMessageLength = 32768
+
+// ValidatedCondition summarizes the given results as a 'Validated'
+// condition. Failures at error severity set the condition to 'False'
+// while validator errors without such failures leave it 'Unknown'.
+// Skipped validators are counted but don't affect the status. Messages
+// which don't fit into the condition are omitted and only counted
+// since they are still recorded in the per-validator results.
+func ValidatedCondition(results ResultList, generation int64) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionValidated,
+		ObservedGeneration: generation,
+	}
+
+	var (
+		failures, errs []string
+		skipped        int
+	)
+
+	for _, res := range results {
+		switch {
+		case res.IsSkipped():
+			skipped++
+		case res.IsSuccess(), res.IsSuppressed():
+			continue
+		case res.IsError():
+			errs = append(errs, fmt.Sprintf("%s %s: %v", res.Code, res.Name, res.Error))
+		default:
+			for _, msg := range res.FailureMsgs {
+				failures = append(failures, fmt.Sprintf("%s %s (%s): %s", res.Code, res.Name, res.Severity, msg))
+			}
+		}
+	}
+
+	switch {
+	case results.HasFailureAtOrAbove(SeverityError):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1alpha1.ReasonValidationFailed
+	case len(errs) > 0:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = v1alpha1.ReasonValidationErrored
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = v1alpha1.ReasonValidationSucceeded
+	}
+
+	msgs := append(failures, errs...)
+	if len(msgs) == 0 {
+		msgs = []string{fmt.Sprintf("%d validators passed.", len(results)-skipped)}
+	}
+
+	var summary string
+
+	if skipped > 0 {
+		summary = fmt.Sprintf("\n%d validators skipped.", skipped)
+	}
+
+	cond.Message = joinBounded(msgs, summary, maxConditionMessageLength)
+
+	return cond
+}
+
+// joinBounded joins the given messages by newlines followed by the
+// summary. Trailing messages are replaced by a count of the omitted
+// messages if the result would exceed the given length.
+func joinBounded(msgs []string, summary string, length int) string {
+	if joined := strings.Join(msgs, "\n") + summary; len(joined) <= length {
+		return joined
+	}
+
+	// reserve room for the widest possible omission note
+	reserved := len(omittedNote(len(msgs))) + len(summary)
+
+	var b strings.Builder
+
+	for i, msg := range msgs {
+		if b.Len()+len(msg)+1+reserved > length {
+			b.WriteString(omittedNote(len(msgs) - i))
+
+			break
+		}
+
+		b.WriteString(msg)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(summary)
+
+	return b.String()
+}
+
+func omittedNote(n int) string {
+	return fmt.Sprintf("%d more messages omitted, see results for details.", n)
+}
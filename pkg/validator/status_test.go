@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetValidationStatus(t *testing.T) {
+	t.Parallel()
+
+	indexImage := "quay.io/osd-addons/reference-addon-index@sha256:abc"
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{IndexImage: &indexImage},
+		Bundles: []operator.Bundle{
+			{
+				Name:        "reference-addon.v0.1.0",
+				Version:     "0.1.0",
+				BundleImage: "quay.io/osd-addons/reference-addon-bundle@sha256:def",
+			},
+		},
+	}
+
+	sup := Suppression{Code: 3, Justification: "Accepted.", Expires: time.Now().Add(time.Hour)}
+
+	results := ResultList{
+		{Code: 1, Name: "first", Severity: SeverityError, success: true},
+		{Code: 2, Name: "second", Severity: SeverityWarning, FailureMsgs: []string{"looks odd"}},
+		{Code: 3, Name: "third", Severity: SeverityError, SuppressedMsgs: []SuppressedMsg{{Msg: "accepted", Suppression: sup}}},
+		{Code: 4, Name: "fourth", Severity: SeverityError, Error: errors.New("unavailable")},
+	}
+
+	status := v1alpha1.ValidationStatus{
+		Conditions: []metav1.Condition{
+			{Type: v1alpha1.ConditionImageSetResolved, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonImageSetFound},
+		},
+		Results: []v1alpha1.ValidatorResult{{Code: "AM0099"}},
+	}
+
+	SetValidationStatus(&status, mb, results, 3)
+
+	assert.Equal(t, int64(3), status.ObservedGeneration)
+	assert.Equal(t, indexImage, status.IndexImage)
+	assert.Equal(t, []v1alpha1.BundleReference{
+		{
+			Name:    "reference-addon.v0.1.0",
+			Version: "0.1.0",
+			Image:   "quay.io/osd-addons/reference-addon-bundle@sha256:def",
+		},
+	}, status.Bundles)
+	assert.Equal(t, []v1alpha1.ValidatorResult{
+		{Code: "AM0001", Name: "first", Severity: "error", Status: v1alpha1.ValidatorResultSuccess},
+		{Code: "AM0002", Name: "second", Severity: "warning", Status: v1alpha1.ValidatorResultFailure, FailureMsgs: []string{"looks odd"}},
+		{Code: "AM0003", Name: "third", Severity: "error", Status: v1alpha1.ValidatorResultSuppressed, SuppressedMsgs: []string{"accepted"}},
+		{Code: "AM0004", Name: "fourth", Severity: "error", Status: v1alpha1.ValidatorResultError, Error: "unavailable"},
+	}, status.Results)
+
+	require.Len(t, status.Conditions, 2)
+
+	validated := apimeta.FindStatusCondition(status.Conditions, v1alpha1.ConditionValidated)
+	require.NotNil(t, validated)
+
+	assert.Equal(t, metav1.ConditionUnknown, validated.Status)
+	assert.Equal(t, v1alpha1.ReasonValidationErrored, validated.Reason)
+	assert.Equal(t, int64(3), validated.ObservedGeneration)
+}
+
+func TestValidatedCondition(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Results         ResultList
+		ExpectedStatus  metav1.ConditionStatus
+		ExpectedReason  string
+		ExpectedMessage string
+	}{
+		"no results": {
+			ExpectedStatus:  metav1.ConditionTrue,
+			ExpectedReason:  v1alpha1.ReasonValidationSucceeded,
+			ExpectedMessage: "0 validators passed.",
+		},
+		"success": {
+			Results: ResultList{
+				{Code: 1, Name: "first", Severity: SeverityError, success: true},
+			},
+			ExpectedStatus:  metav1.ConditionTrue,
+			ExpectedReason:  v1alpha1.ReasonValidationSucceeded,
+			ExpectedMessage: "1 validators passed.",
+		},
+		"skipped": {
+			Results: ResultList{
+				{Code: 1, Name: "first", Severity: SeverityError, success: true},
+				{Code: 2, Name: "second", Severity: SeverityError, SkipReason: "offline", skipped: true},
+			},
+			ExpectedStatus:  metav1.ConditionTrue,
+			ExpectedReason:  v1alpha1.ReasonValidationSucceeded,
+			ExpectedMessage: "1 validators passed.\n1 validators skipped.",
+		},
+		"warning": {
+			Results: ResultList{
+				{Code: 1, Name: "first", Severity: SeverityWarning, FailureMsgs: []string{"looks odd"}},
+			},
+			ExpectedStatus:  metav1.ConditionTrue,
+			ExpectedReason:  v1alpha1.ReasonValidationSucceeded,
+			ExpectedMessage: "AM0001 first (warning): looks odd",
+		},
+		"failure and error": {
+			Results: ResultList{
+				{Code: 1, Name: "first", Severity: SeverityError, FailureMsgs: []string{"is wrong"}},
+				{Code: 2, Name: "second", Severity: SeverityError, Error: errors.New("unavailable")},
+			},
+			ExpectedStatus:  metav1.ConditionFalse,
+			ExpectedReason:  v1alpha1.ReasonValidationFailed,
+			ExpectedMessage: "AM0001 first (error): is wrong\nAM0002 second: unavailable",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cond := ValidatedCondition(tc.Results, 1)
+
+			assert.Equal(t, v1alpha1.ConditionValidated, cond.Type)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			assert.Equal(t, tc.ExpectedMessage, cond.Message)
+		})
+	}
+}
+
+func TestValidatedConditionBoundsMessage(t *testing.T) {
+	t.Parallel()
+
+	msgs := make([]string, 1000)
+	for i := range msgs {
+		msgs[i] = fmt.Sprintf("image %d is missing from relatedImages%s", i, strings.Repeat(".", 100))
+	}
+
+	results := ResultList{
+		{Code: 1, Name: "first", Severity: SeverityError, FailureMsgs: msgs},
+		{Code: 2, Name: "second", Severity: SeverityError, SkipReason: "offline", skipped: true},
+	}
+
+	cond := ValidatedCondition(results, 1)
+
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.LessOrEqual(t, len(cond.Message), maxConditionMessageLength)
+	assert.True(t, strings.HasPrefix(cond.Message, "AM0001 first (error): image 0 is missing"))
+	assert.Regexp(t, `\n\d+ more messages omitted, see results for details\.\n1 validators skipped\.$`, cond.Message)
+}
@@ -0,0 +1,180 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Suppression accepts known failures of a single validator.
+type Suppression struct {
+	// Code is the code of the validator whose failures are accepted.
+	Code Code
+	// Message optionally restricts the suppression to failure
+	// messages matching the expression. A nil Message matches
+	// every failure message.
+	Message *regexp.Regexp
+	// Justification explains why the failures are accepted.
+	Justification string
+	// Expires is the last day on which the suppression
+	// applies.
+	Expires time.Time
+}
+
+// Expired returns 'true' if the suppression is no longer valid
+// at the given time. Suppressions remain valid for the whole of
+// their expiry day.
+func (s Suppression) Expired(now time.Time) bool {
+	return !now.Before(s.Expires.AddDate(0, 0, 1))
+}
+
+// Matches returns 'true' if the given failure message of the
+// validator with the given code is accepted by the suppression.
+func (s Suppression) Matches(code Code, msg string) bool {
+	if s.Code != code {
+		return false
+	}
+
+	return s.Message == nil || s.Message.MatchString(msg)
+}
+
+func (s Suppression) String() string {
+	return fmt.Sprintf("%s (expires %s): %s", s.Code, s.Expires.Format(time.DateOnly), s.Justification)
+}
+
+var (
+	ErrSuppressionMissingJustification = errors.New("suppression requires a justification")
+	ErrSuppressionMissingExpiry        = errors.New("suppression requires an expiry date")
+)
+
+// ParseSuppressions parses a YAML document of suppressions. An
+// error is returned if any suppression is missing its justification
+// or expiry date or contains an invalid code, message expression
+// or date.
+//
+// Example:
+//
+//	suppressions:
+//	- code: AM0012
+//	  message: "Wild card string used under resource"
+//	  justification: "The operator manages arbitrary CRDs by design."
+//	  expires: "2023-12-31"
+func ParseSuppressions(data []byte) ([]Suppression, error) {
+	var doc struct {
+		Suppressions []struct {
+			Code          string `json:"code"`
+			Message       string `json:"message,omitempty"`
+			Justification string `json:"justification"`
+			Expires       string `json:"expires"`
+		} `json:"suppressions"`
+	}
+
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshalling suppressions: %w", err)
+	}
+
+	res := make([]Suppression, 0, len(doc.Suppressions))
+
+	for i, raw := range doc.Suppressions {
+		code, err := ParseCode(raw.Code)
+		if err != nil {
+			return nil, fmt.Errorf("suppression %d: %w", i, err)
+		}
+
+		if raw.Justification == "" {
+			return nil, fmt.Errorf("suppression %d for %s: %w", i, code, ErrSuppressionMissingJustification)
+		}
+
+		if raw.Expires == "" {
+			return nil, fmt.Errorf("suppression %d for %s: %w", i, code, ErrSuppressionMissingExpiry)
+		}
+
+		expires, err := time.Parse(time.DateOnly, raw.Expires)
+		if err != nil {
+			return nil, fmt.Errorf("suppression %d for %s: parsing expiry date: %w", i, code, err)
+		}
+
+		sup := Suppression{
+			Code:          code,
+			Justification: raw.Justification,
+			Expires:       expires,
+		}
+
+		if raw.Message != "" {
+			expr, err := regexp.Compile(raw.Message)
+			if err != nil {
+				return nil, fmt.Errorf("suppression %d for %s: compiling message expression: %w", i, code, err)
+			}
+
+			sup.Message = expr
+		}
+
+		res = append(res, sup)
+	}
+
+	return res, nil
+}
+
+// Suppress applies the given suppressions to the failed members
+// of the ResultList. Failure messages accepted by a suppression
+// are moved from FailureMsgs to SuppressedMsgs so that every
+// Result keeps its Code. A Result whose messages were all accepted
+// is considered suppressed. Suppressions which have expired at the
+// given time are not applied and are returned so that they can be
+// reported.
+func (l ResultList) Suppress(now time.Time, sups ...Suppression) (ResultList, []Suppression) {
+	var (
+		active  []Suppression
+		expired []Suppression
+	)
+
+	for _, sup := range sups {
+		if sup.Expired(now) {
+			expired = append(expired, sup)
+
+			continue
+		}
+
+		active = append(active, sup)
+	}
+
+	if len(active) == 0 {
+		return l, expired
+	}
+
+	res := make(ResultList, 0, len(l))
+
+	for _, r := range l {
+		if r.IsSuccess() || r.IsSkipped() || r.IsError() || r.IsSuppressed() {
+			res = append(res, r)
+
+			continue
+		}
+
+		var remaining []string
+
+	msgs:
+		for _, msg := range r.FailureMsgs {
+			for _, sup := range active {
+				if sup.Matches(r.Code, msg) {
+					r.SuppressedMsgs = append(r.SuppressedMsgs, SuppressedMsg{
+						Msg:         msg,
+						Suppression: sup,
+					})
+
+					continue msgs
+				}
+			}
+
+			remaining = append(remaining, msg)
+		}
+
+		r.FailureMsgs = remaining
+		res = append(res, r)
+	}
+
+	return res, expired
+}
@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input          string
+		Expected       []Suppression
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			Input:          "",
+			Expected:       []Suppression{},
+			ErrorAssertion: assert.NoError,
+		},
+		"valid": {
+			Input: `
+suppressions:
+- code: AM0012
+  message: "^Wild card"
+  justification: "Manages arbitrary CRDs."
+  expires: "2030-01-01"
+- code: AM0015
+  justification: "Migrating to per-version channels."
+  expires: "2030-06-30"
+`,
+			Expected: []Suppression{
+				{
+					Code:          12,
+					Message:       regexp.MustCompile("^Wild card"),
+					Justification: "Manages arbitrary CRDs.",
+					Expires:       time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Code:          15,
+					Justification: "Migrating to per-version channels.",
+					Expires:       time.Date(2030, time.June, 30, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			ErrorAssertion: assert.NoError,
+		},
+		"missing justification": {
+			Input: `
+suppressions:
+- code: AM0012
+  expires: "2030-01-01"
+`,
+			ErrorAssertion: assert.Error,
+		},
+		"missing expiry": {
+			Input: `
+suppressions:
+- code: AM0012
+  justification: "Manages arbitrary CRDs."
+`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid code": {
+			Input: `
+suppressions:
+- code: XX0012
+  justification: "Manages arbitrary CRDs."
+  expires: "2030-01-01"
+`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid message": {
+			Input: `
+suppressions:
+- code: AM0012
+  message: "("
+  justification: "Manages arbitrary CRDs."
+  expires: "2030-01-01"
+`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid date": {
+			Input: `
+suppressions:
+- code: AM0012
+  justification: "Manages arbitrary CRDs."
+  expires: "01/01/2030"
+`,
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sups, err := ParseSuppressions([]byte(tc.Input))
+			tc.ErrorAssertion(t, err)
+
+			assert.Equal(t, tc.Expected, sups)
+		})
+	}
+}
+
+func TestSuppressionExpired(t *testing.T) {
+	t.Parallel()
+
+	sup := Suppression{
+		Code:          12,
+		Justification: "Manages arbitrary CRDs.",
+		Expires:       time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for name, tc := range map[string]struct {
+		Now      time.Time
+		Expected bool
+	}{
+		"day before expiry": {
+			Now:      time.Date(2029, time.December, 31, 23, 59, 59, 0, time.UTC),
+			Expected: false,
+		},
+		"start of expiry day": {
+			Now:      time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected: false,
+		},
+		"end of expiry day": {
+			Now:      time.Date(2030, time.January, 1, 23, 59, 59, 0, time.UTC),
+			Expected: false,
+		},
+		"day after expiry": {
+			Now:      time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Expected: true,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, sup.Expired(tc.Now))
+		})
+	}
+}
+
+func TestResultListSuppress(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	active := Suppression{
+		Code:          2,
+		Message:       regexp.MustCompile("^first"),
+		Justification: "accepted",
+		Expires:       now.AddDate(0, 1, 0),
+	}
+
+	expired := Suppression{
+		Code:          3,
+		Justification: "no longer accepted",
+		Expires:       now.AddDate(0, -1, 0),
+	}
+
+	all := Suppression{
+		Code:          4,
+		Justification: "accepted",
+		Expires:       now.AddDate(1, 0, 0),
+	}
+
+	results := ResultList{
+		Result{Code: 1, success: true},
+		Result{Code: 2, FailureMsgs: []string{"first", "second"}},
+		Result{Code: 3, FailureMsgs: []string{"first"}},
+		Result{Code: 4, FailureMsgs: []string{"first", "second"}},
+		Result{Code: 5, Error: errors.New("boom")},
+	}
+
+	res, expiredSups := results.Suppress(now, active, expired, all)
+
+	assert.Equal(t, []Suppression{expired}, expiredSups)
+	assert.Equal(t, ResultList{
+		Result{Code: 1, success: true},
+		Result{
+			Code:           2,
+			FailureMsgs:    []string{"second"},
+			SuppressedMsgs: []SuppressedMsg{{Msg: "first", Suppression: active}},
+		},
+		Result{Code: 3, FailureMsgs: []string{"first"}},
+		Result{
+			Code: 4,
+			SuppressedMsgs: []SuppressedMsg{
+				{Msg: "first", Suppression: all},
+				{Msg: "second", Suppression: all},
+			},
+		},
+		Result{Code: 5, Error: errors.New("boom")},
+	}, res)
+
+	assert.False(t, res[1].IsSuppressed())
+	assert.True(t, res[3].IsSuppressed())
+}
+
+func TestResultListHasFailureIgnoresSuppressed(t *testing.T) {
+	t.Parallel()
+
+	sup := Suppression{Code: 1, Justification: "accepted"}
+
+	results := ResultList{
+		Result{Code: 1, success: true},
+		Result{Code: 1, Severity: SeverityError, SuppressedMsgs: []SuppressedMsg{{Msg: "first", Suppression: sup}}},
+	}
+
+	require.False(t, results.HasFailure())
+	require.False(t, results.HasFailureAtOrAbove(SeverityInfo))
+}
@@ -0,0 +1,287 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeRegistryToken = "fake-registry-token"
+
+// NewFakeRegistry starts an in-memory registry serving the OCI
+// distribution API over TLS. The registry is stopped once the
+// test completes.
+func NewFakeRegistry(t *testing.T) *FakeRegistry {
+	t.Helper()
+
+	reg := &FakeRegistry{
+		t:         t,
+		manifests: make(map[string]fakeManifest),
+		blobs:     make(map[string][]byte),
+	}
+
+	reg.srv = httptest.NewTLSServer(http.HandlerFunc(reg.serve))
+	t.Cleanup(reg.srv.Close)
+
+	return reg
+}
+
+// FakeRegistry is an in-memory registry to test registry clients
+// and validators against. Images are pushed with PushImage and
+// PushIndex and can be pulled from the repositories of Host.
+type FakeRegistry struct {
+	t   *testing.T
+	srv *httptest.Server
+
+	lock      sync.RWMutex
+	manifests map[string]fakeManifest
+	blobs     map[string][]byte
+	username  string
+	password  string
+}
+
+type fakeManifest struct {
+	mediaType string
+	data      []byte
+}
+
+// Host returns the host of the registry to reference images with.
+func (r *FakeRegistry) Host() string {
+	return strings.TrimPrefix(r.srv.URL, "https://")
+}
+
+// Client returns a registry client trusting the certificate
+// of the registry configured with the given options.
+func (r *FakeRegistry) Client(opts ...validator.RegistryClientOption) *validator.DefaultV2RegistryClient {
+	return validator.NewDefaultV2RegistryClient(
+		append(opts, validator.WithHTTPClient{Client: r.srv.Client()})...,
+	)
+}
+
+// RequireAuth challenges anonymous requests with a bearer token
+// challenge. Tokens are only issued for the given credentials.
+func (r *FakeRegistry) RequireAuth(username, password string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.username = username
+	r.password = password
+}
+
+// PushImage pushes a single image built for the given platform to
+// the given repository, tags it and returns the digest of its manifest.
+func (r *FakeRegistry) PushImage(repo, tag string, platform validator.Platform) string {
+	r.t.Helper()
+
+	desc := r.pushManifest(repo, platform)
+	r.tag(repo, tag, desc.Digest.String())
+
+	return desc.Digest.String()
+}
+
+// PushIndex pushes an image index referencing one image for each of the
+// given platforms to the given repository, tags it and returns the
+// digest of the index.
+func (r *FakeRegistry) PushIndex(repo, tag string, platforms ...validator.Platform) string {
+	r.t.Helper()
+
+	index := ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+	}
+	index.SchemaVersion = 2
+
+	for _, platform := range platforms {
+		desc := r.pushManifest(repo, platform)
+		desc.Platform = &ocispec.Platform{
+			OS:           platform.OS,
+			Architecture: platform.Architecture,
+			Variant:      platform.Variant,
+		}
+
+		index.Manifests = append(index.Manifests, desc)
+	}
+
+	desc := r.put(repo, ocispec.MediaTypeImageIndex, index)
+	r.tag(repo, tag, desc.Digest.String())
+
+	return desc.Digest.String()
+}
+
+func (r *FakeRegistry) pushManifest(repo string, platform validator.Platform) ocispec.Descriptor {
+	r.t.Helper()
+
+	var config ocispec.Image
+
+	config.OS = platform.OS
+	config.Architecture = platform.Architecture
+	config.Variant = platform.Variant
+
+	data, err := json.Marshal(config)
+	require.NoError(r.t, err)
+
+	configDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageConfig,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+
+	r.lock.Lock()
+	r.blobs[configDesc.Digest.String()] = data
+	r.lock.Unlock()
+
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispec.Descriptor{},
+	}
+	manifest.SchemaVersion = 2
+
+	return r.put(repo, ocispec.MediaTypeImageManifest, manifest)
+}
+
+// put stores the given manifest by digest.
+func (r *FakeRegistry) put(repo, mediaType string, manifest any) ocispec.Descriptor {
+	r.t.Helper()
+
+	data, err := json.Marshal(manifest)
+	require.NoError(r.t, err)
+
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+
+	r.lock.Lock()
+	r.manifests[repo+"@"+desc.Digest.String()] = fakeManifest{mediaType: mediaType, data: data}
+	r.lock.Unlock()
+
+	return desc
+}
+
+func (r *FakeRegistry) tag(repo, tag, dgst string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.manifests[repo+":"+tag] = r.manifests[repo+"@"+dgst]
+}
+
+func (r *FakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.serveToken(w, req)
+
+		return
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, "/v2/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	if i := strings.LastIndex(path, "/manifests/"); i >= 0 {
+		repo, ref := path[:i], path[i+len("/manifests/"):]
+
+		if r.challenge(w, req, repo) {
+			r.serveManifest(w, req, repo, ref)
+		}
+
+		return
+	}
+
+	if i := strings.LastIndex(path, "/blobs/"); i >= 0 {
+		repo, dgst := path[:i], path[i+len("/blobs/"):]
+
+		if r.challenge(w, req, repo) {
+			r.serveBlob(w, dgst)
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// challenge responds with a bearer token challenge and returns 'false'
+// if authentication is required and the request lacks a valid token.
+func (r *FakeRegistry) challenge(w http.ResponseWriter, req *http.Request, repo string) bool {
+	r.lock.RLock()
+	required := r.username != "" || r.password != ""
+	r.lock.RUnlock()
+
+	if !required || req.Header.Get("Authorization") == "Bearer "+fakeRegistryToken {
+		return true
+	}
+
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+		`Bearer realm="%s/token",service="fake-registry",scope="repository:%s:pull"`, r.srv.URL, repo,
+	))
+	w.WriteHeader(http.StatusUnauthorized)
+
+	return false
+}
+
+func (r *FakeRegistry) serveToken(w http.ResponseWriter, req *http.Request) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"token": fakeRegistryToken})
+}
+
+func (r *FakeRegistry) serveManifest(w http.ResponseWriter, req *http.Request, repo, ref string) {
+	sep := ":"
+	if strings.HasPrefix(ref, "sha256:") {
+		sep = "@"
+	}
+
+	r.lock.RLock()
+	manifest, ok := r.manifests[repo+sep+ref]
+	r.lock.RUnlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", manifest.mediaType)
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest.data).String())
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = w.Write(manifest.data)
+}
+
+func (r *FakeRegistry) serveBlob(w http.ResponseWriter, dgst string) {
+	r.lock.RLock()
+	blob, ok := r.blobs[dgst]
+	r.lock.RUnlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	_, _ = w.Write(blob)
+}
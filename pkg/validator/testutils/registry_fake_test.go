@@ -0,0 +1,138 @@
+package testutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	imageparser "github.com/novln/docker-parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	linuxAMD64 = validator.Platform{OS: "linux", Architecture: "amd64"}
+	linuxARM64 = validator.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+)
+
+func TestFakeRegistryClientInterfaces(t *testing.T) {
+	t.Parallel()
+
+	reg := NewFakeRegistry(t)
+
+	require.Implements(t, new(validator.RegistryClient), reg.Client())
+}
+
+func TestFakeRegistry(t *testing.T) {
+	t.Parallel()
+
+	reg := NewFakeRegistry(t)
+	imageDigest := reg.PushImage("app-sre/single", "v1.0.0", linuxAMD64)
+	indexDigest := reg.PushIndex("app-sre/multi", "v1.0.0", linuxAMD64, linuxARM64)
+
+	client := reg.Client()
+
+	for name, tc := range map[string]struct {
+		Image     string
+		Exists    bool
+		Digest    string
+		Platforms []validator.Platform
+	}{
+		"single image by tag": {
+			Image:     reg.Host() + "/app-sre/single:v1.0.0",
+			Exists:    true,
+			Digest:    imageDigest,
+			Platforms: []validator.Platform{linuxAMD64},
+		},
+		"single image by digest": {
+			Image:     reg.Host() + "/app-sre/single@" + imageDigest,
+			Exists:    true,
+			Digest:    imageDigest,
+			Platforms: []validator.Platform{linuxAMD64},
+		},
+		"index by tag": {
+			Image:     reg.Host() + "/app-sre/multi:v1.0.0",
+			Exists:    true,
+			Digest:    indexDigest,
+			Platforms: []validator.Platform{linuxAMD64, linuxARM64},
+		},
+		"missing tag": {
+			Image: reg.Host() + "/app-sre/multi:v2.0.0",
+		},
+		"missing digest": {
+			Image: reg.Host() + "/app-sre/multi@sha256:bdc32a600202d36fec4524dbec177e9313ef82ad4bda5bd24d4b75236ca8a482",
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := imageparser.Parse(tc.Image)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+
+			exists, err := client.HasReference(ctx, ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Exists, exists)
+
+			digest, err := client.ResolveDigest(ctx, ref)
+			platforms, platformsErr := client.Platforms(ctx, ref)
+
+			if !tc.Exists {
+				assert.ErrorIs(t, err, validator.ErrImageNotFound)
+				assert.ErrorIs(t, platformsErr, validator.ErrImageNotFound)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, platformsErr)
+			assert.Equal(t, tc.Digest, digest)
+			assert.ElementsMatch(t, tc.Platforms, platforms)
+		})
+	}
+}
+
+func TestFakeRegistryAuth(t *testing.T) {
+	t.Parallel()
+
+	reg := NewFakeRegistry(t)
+	reg.RequireAuth("robot", "secret")
+	reg.PushIndex("app-sre/private", "v1.0.0", linuxAMD64, linuxARM64)
+
+	ref, err := imageparser.Parse(reg.Host() + "/app-sre/private:v1.0.0")
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Credentials validator.RegistryCredentials
+		Exists      bool
+	}{
+		"anonymous": {
+			Exists: false,
+		},
+		"invalid credentials": {
+			Credentials: validator.RegistryCredentials{Username: "robot", Password: "wrong"},
+			Exists:      false,
+		},
+		"valid credentials": {
+			Credentials: validator.RegistryCredentials{Username: "robot", Password: "secret"},
+			Exists:      true,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := reg.Client(validator.WithRegistryCredentials{
+				reg.Host(): tc.Credentials,
+			})
+
+			exists, err := client.HasReference(context.Background(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Exists, exists)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package testutils
+
+import (
+	"context"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/mock"
+)
+
+func NewMockRegistryClient() *MockRegistryClient {
+	return &MockRegistryClient{}
+}
+
+// Deprecated: use NewMockRegistryClient instead.
+func NewMockQuayClient() *MockQuayClient {
+	return NewMockRegistryClient()
+}
+
+// Deprecated: use MockRegistryClient instead.
+type MockQuayClient = MockRegistryClient
+
+type MockRegistryClient struct {
+	mock.Mock
+}
+
+func (c *MockRegistryClient) HasReference(ctx context.Context, ref validator.ImageReference) (bool, error) {
+	args := c.Called(ctx, ref)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (c *MockRegistryClient) ResolveDigest(ctx context.Context, ref validator.ImageReference) (string, error) {
+	args := c.Called(ctx, ref)
+
+	return args.String(0), args.Error(1)
+}
+
+func (c *MockRegistryClient) Platforms(ctx context.Context, ref validator.ImageReference) ([]validator.Platform, error) {
+	args := c.Called(ctx, ref)
+
+	platforms, _ := args.Get(0).([]validator.Platform)
+
+	return platforms, args.Error(1)
+}
@@ -8,6 +8,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestMockQuayClientInterfaces(t *testing.T) {
-	require.Implements(t, new(validator.QuayClient), new(MockQuayClient))
+func TestMockRegistryClientInterfaces(t *testing.T) {
+	require.Implements(t, new(validator.RegistryClient), new(MockRegistryClient))
 }
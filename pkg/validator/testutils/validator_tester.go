@@ -30,9 +30,9 @@ func NewValidatorTester(t *testing.T, init validator.Initializer, opts ...Valida
 
 	// This also ensures that a validator implements the validator.Validator interface
 	vt.Val, err = init(validator.Dependencies{
-		Logger:     vt.log,
-		OCMClient:  vt.ocm,
-		QuayClient: vt.quay,
+		Logger:         vt.log,
+		OCMClient:      vt.ocm,
+		RegistryClient: vt.registry,
 	})
 	require.NoError(t, err)
 
@@ -41,10 +41,10 @@ func NewValidatorTester(t *testing.T, init validator.Initializer, opts ...Valida
 
 type ValidatorTester struct {
 	*testing.T
-	Val  validator.Validator
-	log  logr.Logger
-	ocm  validator.OCMClient
-	quay validator.QuayClient
+	Val      validator.Validator
+	log      logr.Logger
+	ocm      validator.OCMClient
+	registry validator.RegistryClient
 }
 
 func (v *ValidatorTester) TestSingleBundle(mb types.MetaBundle) validator.Result {
@@ -63,6 +63,22 @@ func (v *ValidatorTester) TestInvalidBundles(bundles map[string]types.MetaBundle
 	v.testBundles(bundles, assert.False)
 }
 
+func (v *ValidatorTester) TestSkippedBundles(bundles map[string]types.MetaBundle) {
+	v.Helper()
+
+	for name, bundle := range bundles {
+		bundle := bundle
+
+		v.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := v.Val.Run(context.Background(), bundle)
+			assert.True(t, res.IsSkipped(), "Actual Result: %+v", res)
+			assert.NotEmpty(t, res.SkipReason)
+		})
+	}
+}
+
 func (v *ValidatorTester) testBundles(bundles map[string]types.MetaBundle, assert assert.BoolAssertionFunc) {
 	v.Helper()
 
@@ -95,12 +111,17 @@ func ValidatorTesterOCMClient(ocm validator.OCMClient) ValidatorTesterOption {
 	}
 }
 
-func ValidatorTesterQuayClient(quay validator.QuayClient) ValidatorTesterOption {
+func ValidatorTesterRegistryClient(registry validator.RegistryClient) ValidatorTesterOption {
 	return func(v *ValidatorTester) {
-		v.quay = quay
+		v.registry = registry
 	}
 }
 
+// Deprecated: use ValidatorTesterRegistryClient instead.
+func ValidatorTesterQuayClient(quay validator.QuayClient) ValidatorTesterOption {
+	return ValidatorTesterRegistryClient(quay)
+}
+
 func DefaultValidBundleMap() (map[string]types.MetaBundle, error) {
 	res := make(map[string]types.MetaBundle)
 
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mt-sre/addon-metadata-operator/pkg/validator"
+
+// NewTracingMiddleware returns middleware which records every
+// validator run as an OpenTelemetry span. Spans are created by
+// the global TracerProvider unless another one is given.
+func NewTracingMiddleware(opts ...TracingMiddlewareOption) *TracingMiddleware {
+	var cfg TracingMiddlewareConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &TracingMiddleware{
+		tracer: cfg.TracerProvider.Tracer(tracerName),
+	}
+}
+
+type TracingMiddleware struct {
+	tracer trace.Tracer
+}
+
+func (t *TracingMiddleware) Wrap(run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) Result {
+		spanName := "validator"
+
+		var attrs []attribute.KeyValue
+
+		if v, ok := ValidatorFromContext(ctx); ok {
+			spanName = fmt.Sprintf("validator %s", v.Code())
+			attrs = append(attrs,
+				attribute.String("validator.code", v.Code().String()),
+				attribute.String("validator.name", v.Name()),
+				attribute.String("validator.severity", v.Severity().String()),
+			)
+		}
+
+		if mb.AddonMeta != nil {
+			attrs = append(attrs, attribute.String("addon.id", mb.AddonMeta.ID))
+		}
+
+		ctx, span := t.tracer.Start(ctx, spanName, trace.WithAttributes(attrs...))
+		defer span.End()
+
+		res := run(ctx, mb)
+
+		span.SetAttributes(
+			attribute.String("validator.outcome", res.Outcome()),
+			attribute.Int("validator.attempts", max(res.Attempts, 1)),
+		)
+
+		switch {
+		case res.IsError():
+			span.RecordError(res.Error)
+			span.SetStatus(codes.Error, res.Error.Error())
+		case res.IsSkipped():
+			span.SetAttributes(attribute.String("validator.skip_reason", res.SkipReason))
+		case !res.IsSuccess():
+			span.SetAttributes(attribute.StringSlice("validator.failures", res.FailureMsgs))
+		}
+
+		return res
+	}
+}
+
+type TracingMiddlewareConfig struct {
+	TracerProvider trace.TracerProvider
+}
+
+func (c *TracingMiddlewareConfig) Option(opts ...TracingMiddlewareOption) {
+	for _, opt := range opts {
+		opt.ConfigureTracingMiddleware(c)
+	}
+}
+
+func (c *TracingMiddlewareConfig) Default() {
+	if c.TracerProvider == nil {
+		c.TracerProvider = otel.GetTracerProvider()
+	}
+}
+
+type TracingMiddlewareOption interface {
+	ConfigureTracingMiddleware(*TracingMiddlewareConfig)
+}
+
+type WithTracerProvider struct{ trace.TracerProvider }
+
+func (w WithTracerProvider) ConfigureTracingMiddleware(c *TracingMiddlewareConfig) {
+	c.TracerProvider = w.TracerProvider
+}
@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	runner, err := NewRunner(
+		WithInitializers{
+			NewValidatorMock(1, "passing", "passes", func(context.Context, types.MetaBundle) Result {
+				return Result{success: true}
+			}),
+			NewValidatorMock(2, "erroring", "errors", func(context.Context, types.MetaBundle) Result {
+				return Result{Error: errors.New("boom")}
+			}),
+		},
+		WithMiddleware{
+			NewTracingMiddleware(WithTracerProvider{TracerProvider: provider}),
+		},
+	)
+	require.NoError(t, err)
+
+	for range runner.Run(context.Background(), types.MetaBundle{}) {
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Name() < spans[j].Name() })
+
+	assert.Equal(t, "validator AM0001", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("validator.name", "passing"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("validator.outcome", "success"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "validator AM0002", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("validator.outcome", "error"))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "boom", spans[1].Status().Description)
+}
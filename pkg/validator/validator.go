@@ -2,6 +2,7 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -17,6 +18,15 @@ type Validator interface {
 	Name() string
 	// Description returns the displayed description of a Validator instance.
 	Description() string
+	// Severity returns the severity with which failures of a Validator
+	// instance are reported.
+	Severity() Severity
+	// Capabilities returns the resources a Validator instance
+	// requires in order to run.
+	Capabilities() []Capability
+	// Prerequisites returns the codes of the validators which must
+	// succeed before a Validator instance is run.
+	Prerequisites() []Code
 	// Run executes validation tasks against a types.MetaBundle and returns the
 	// result of that task. A context.Context instance is also passed to allow
 	// for cancellation and timeouts to propogate through the validation task
@@ -41,14 +51,20 @@ func NewBase(code Code, opts ...BaseOption) (*Base, error) {
 
 // Base implements the base functionality used by Validator instances.
 type Base struct {
-	code Code
-	name string
-	desc string
+	code     Code
+	name     string
+	desc     string
+	severity Severity
+	caps     []Capability
+	prereqs  []Code
 }
 
-func (b *Base) Code() Code          { return b.code }
-func (b *Base) Name() string        { return b.name }
-func (b *Base) Description() string { return b.desc }
+func (b *Base) Code() Code                 { return b.code }
+func (b *Base) Name() string               { return b.name }
+func (b *Base) Description() string        { return b.desc }
+func (b *Base) Severity() Severity         { return b.severity }
+func (b *Base) Capabilities() []Capability { return b.caps }
+func (b *Base) Prerequisites() []Code      { return b.prereqs }
 
 // Option applies a variadic slice of options to a Base instance.
 func (b *Base) Option(opts ...BaseOption) {
@@ -66,6 +82,10 @@ func (b *Base) Default() {
 	if b.desc == "" {
 		b.desc = "no description available"
 	}
+
+	if b.severity == Severity(0) {
+		b.severity = SeverityError
+	}
 }
 
 // Success is a helper which returns a populated Success result.
@@ -96,6 +116,17 @@ func (b *Base) Error(err error) Result {
 	return res
 }
 
+// Skip is a helper which returns a populated Skipped result.
+// A reason is passed to explain why a validation task could
+// not check anything e.g. because its input is missing.
+func (b *Base) Skip(reason string) Result {
+	res := b.populateResult()
+	res.SkipReason = reason
+	res.skipped = true
+
+	return res
+}
+
 // RetryableError is a helper which returns a populated RetryableError result.
 // A RetryableError indicates to middleware that the error is temporary and
 // may be retried.
@@ -107,11 +138,27 @@ func (b *Base) RetryableError(err error) Result {
 	return res
 }
 
+// Errors is a helper which returns a populated Error result joining
+// the given errors. The result is a RetryableError if all of the
+// errors were caused server-side.
+func (b *Base) Errors(errs ...error) Result {
+	err := errors.Join(errs...)
+
+	for _, e := range errs {
+		if !IsServerSideError(e) {
+			return b.Error(err)
+		}
+	}
+
+	return b.RetryableError(err)
+}
+
 func (b *Base) populateResult() Result {
 	return Result{
 		Code:        b.code,
 		Name:        b.name,
 		Description: b.desc,
+		Severity:    b.severity,
 	}
 }
 
@@ -129,6 +176,26 @@ func BaseDesc(desc string) BaseOption {
 	return func(b *Base) { b.desc = desc }
 }
 
+// BaseSeverity applies the given severity to a base instance.
+// Validators default to SeverityError if no severity is given.
+func BaseSeverity(sev Severity) BaseOption {
+	return func(b *Base) { b.severity = sev }
+}
+
+// BaseCapabilities declares the resources a validator requires in
+// order to run. Validators requiring no capabilities can run on
+// addon metadata alone.
+func BaseCapabilities(caps ...Capability) BaseOption {
+	return func(b *Base) { b.caps = append(b.caps, caps...) }
+}
+
+// BasePrerequisites declares the codes of the validators which must
+// succeed before a validator is run. Validators whose prerequisites
+// fail, error or are skipped are reported as skipped.
+func BasePrerequisites(codes ...Code) BaseOption {
+	return func(b *Base) { b.prereqs = append(b.prereqs, codes...) }
+}
+
 // ValidatorList is a sortable slice of Validators.
 type ValidatorList []Validator
 
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Errs      []error
+		Retryable bool
+	}{
+		"all server-side": {
+			Errs:      []error{RegistryResponseError(503), OCMResponseError(500)},
+			Retryable: true,
+		},
+		"mixed": {
+			Errs:      []error{RegistryResponseError(503), errors.New("boom")},
+			Retryable: false,
+		},
+		"client-side": {
+			Errs:      []error{RegistryResponseError(429)},
+			Retryable: false,
+		},
+	} {
+		tc := tc // pin
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			base, err := NewBase(1)
+			require.NoError(t, err)
+
+			res := base.Errors(tc.Errs...)
+			require.True(t, res.IsError())
+			assert.Equal(t, tc.Retryable, res.IsRetryableError())
+
+			for _, e := range tc.Errs {
+				assert.ErrorIs(t, res.Error, e)
+			}
+		})
+	}
+}